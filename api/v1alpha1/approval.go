@@ -0,0 +1,63 @@
+package v1alpha1
+
+import (
+	"strings"
+)
+
+// ApprovalRequired reports whether requests for env must be approved before provisioning.
+func ApprovalRequired(spec *TenantSpec, env string) bool {
+	if spec.Approval == nil {
+		return false
+	}
+	for _, e := range spec.Approval.Envs {
+		if e == env {
+			return true
+		}
+	}
+	return false
+}
+
+// ApproverGroups returns the groups allowed to approve, defaulting to <tenant>:ns-admin.
+func ApproverGroups(spec *TenantSpec, tenant string) []string {
+	if spec.Approval != nil && len(spec.Approval.ApproverGroups) > 0 {
+		return spec.Approval.ApproverGroups
+	}
+	return []string{tenant + ":ns-admin"}
+}
+
+// RequiredApprovals returns the number of distinct approvers needed (at least 1).
+func RequiredApprovals(spec *TenantSpec) int {
+	if spec.Approval == nil || spec.Approval.RequiredApprovals < 1 {
+		return 1
+	}
+	return int(spec.Approval.RequiredApprovals)
+}
+
+// ApprovedBy parses the approved-by annotation written by the webhook.
+func ApprovedBy(nr *NamespaceRequest) []string {
+	raw := strings.TrimSpace(nr.Annotations[AnnApprovedBy])
+	if raw == "" {
+		return nil
+	}
+	var out []string
+	for _, u := range strings.Split(raw, ",") {
+		if u = strings.TrimSpace(u); u != "" {
+			out = append(out, u)
+		}
+	}
+	return out
+}
+
+// CountApprovals counts approvers that satisfy the policy (self-approval excluded unless allowed).
+func CountApprovals(spec *TenantSpec, nr *NamespaceRequest) int {
+	requester := nr.Annotations[AnnRequestedBy]
+	allowSelf := spec.Approval != nil && spec.Approval.AllowSelfApproval
+	n := 0
+	for _, u := range ApprovedBy(nr) {
+		if u == requester && !allowSelf {
+			continue
+		}
+		n++
+	}
+	return n
+}
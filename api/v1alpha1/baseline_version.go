@@ -0,0 +1,30 @@
+package v1alpha1
+
+import "slices"
+
+// BaselineVersions lists the baseline renderer versions, oldest first.
+// Namespaces are migrated one step at a time along this list.
+var BaselineVersions = []string{BaselineVersionV1, BaselineVersionV2}
+
+// BaselineVersionOf returns the baseline version a Tenant asks for; empty means v1.
+func BaselineVersionOf(spec *TenantSpec) string {
+	if spec.Baseline == nil || spec.Baseline.Version == "" {
+		return BaselineVersionV1
+	}
+	return spec.Baseline.Version
+}
+
+// AppliedBaselineVersion returns the baseline version recorded on a namespace.
+// Namespaces provisioned before versioning (or with an unknown value) are treated as v1.
+func AppliedBaselineVersion(nsAnnotations map[string]string) string {
+	v := nsAnnotations[AnnBaselineVersion]
+	if !slices.Contains(BaselineVersions, v) {
+		return BaselineVersionV1
+	}
+	return v
+}
+
+// CompareBaselineVersions returns <0, 0 or >0 when a is older than, equal to or newer than b.
+func CompareBaselineVersions(a, b string) int {
+	return slices.Index(BaselineVersions, a) - slices.Index(BaselineVersions, b)
+}
@@ -0,0 +1,20 @@
+package v1alpha1
+
+// BaselineComponents are the keys of TenantConflictSpec.ByComponent.
+// Each maps to the NamespaceRequest condition "<component>Ready".
+var BaselineComponents = []string{"RBAC", "ServiceAccounts", "Propagation", "Quota", "LimitRange", "NetworkPolicy", "Manifests"}
+
+// ConflictPolicyFor returns the server-side apply conflict policy of a baseline component.
+func ConflictPolicyFor(spec *TenantSpec, component string) string {
+	if spec == nil || spec.Baseline == nil || spec.Baseline.Conflicts == nil {
+		return ConflictPolicyForce
+	}
+	c := spec.Baseline.Conflicts
+	if p := c.ByComponent[component]; p != "" {
+		return p
+	}
+	if c.Default != "" {
+		return c.Default
+	}
+	return ConflictPolicyForce
+}
@@ -9,4 +9,41 @@ const (
 	AnnOwnerGroupRaw    = "guardian.io/owner-group-raw" // 推荐：raw 放 annotation
 	AnnRequestRaw       = "guardian.io/request-raw"     // 推荐：request 原文放 annotation
 	LabelRequestHash    = "guardian.io/request-hash"    // 推荐：request 用 hash label
+	LabelRequest        = "guardian.io/request"         // namespace 上记录所属 NamespaceRequest
+
+	LabelRoleCatalog = "guardian.io/role-catalog" // ClusterRole 打上 "true"：全局允许通过 extraBindings 绑定
+
+	AnnPropagatedFrom = "guardian.io/propagated-from" // 复制到 namespace 里的 Secret/ConfigMap 的来源（<namespace>/<name>）
+	LabelPropagation  = "guardian.io/propagation"     // 来源 Secret/ConfigMap 须由平台打上 "source" 才允许复制；复制品为 "copy"
+	PropagationSource = "source"
+	PropagationCopy   = "copy"
+
+	AnnBaselineVersion = "guardian.io/baseline-version" // namespace 上已下发（迁移完成）的 baseline 版本，没有表示 v1
+
+	AnnProtect   = "guardian.io/protect"    // baseline 对象打上 "true"：不再需要时也不清理（仍会下发/修复）
+	AnnTakenOver = "guardian.io/taken-over" // 接管 namespace 时按确认过的 plan 覆盖的已有对象为 "true"，prune 不删
+
+	LabelOrphaned   = "guardian.io/orphaned"      // namespace 已脱离 NamespaceRequest，等待回收/重新认领
+	AnnReclaimAfter = "guardian.io/reclaim-after" // orphaned namespace 的回收时间（RFC3339）
+
+	AnnRequestedBy       = "guardian.io/requested-by"        // 创建者 username，由 mutating webhook 写入，不可修改
+	AnnRequestedByGroups = "guardian.io/requested-by-groups" // 创建时创建者所在的组（逗号分隔），同上
+	AnnRequestUID        = "guardian.io/request-uid"         // 创建时 admission 请求的 UID，同上
+	AnnRequestedAt       = "guardian.io/requested-at"        // 创建时间（RFC3339），同上
+	AnnApprove           = "guardian.io/approve"             // 审批人打上 "true"，webhook 换成 approved-by 里的 username
+	AnnApprovedBy        = "guardian.io/approved-by"         // 已审批人 username 列表（逗号分隔），只能由 webhook 追加
+
+	AnnExtendTTL = "guardian.io/extend-ttl" // owner 打上时长（如 72h），webhook 换算成延长后的 spec.ttl
+
+	AnnAdoptConfirm = "guardian.io/adopt-confirm" // 值须等于 status.adoption.planHash，确认接管
+
+	AnnTransferTo          = "guardian.io/transfer-to"           // 当前 owner 发起移交：目标 ownerGroup
+	AnnTransferAccept      = "guardian.io/transfer-accept"       // 新 owner 组成员或 tenant admin 打上 "true" 接受移交
+	AnnTransferRequestedBy = "guardian.io/transfer-requested-by" // 发起人 username，由 mutating webhook 写入
+	AnnTransferAcceptedBy  = "guardian.io/transfer-accepted-by"  // 接受人 username，由 mutating webhook 写入
+
+	AnnRetry = "guardian.io/retry" // 打上任意值立即重试 Failed/Retrying 的 request，controller 处理后移除
+
+	FinalizerTenant           = "guardian.io/tenant-cleanup"
+	FinalizerNamespaceRequest = "guardian.io/namespace-reclaim"
 )
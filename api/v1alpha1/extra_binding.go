@@ -0,0 +1,23 @@
+package v1alpha1
+
+// ExtraBindingRoleBindingPrefix names the RoleBindings created for NamespaceRequest.spec.extraBindings.
+const ExtraBindingRoleBindingPrefix = "guardian-extra-"
+
+// ExtraBindingName returns the RoleBinding name for b. The name hashes subject and role,
+// so changing either creates a new RoleBinding instead of mutating the immutable roleRef.
+func ExtraBindingName(b ExtraBinding) string {
+	return ExtraBindingRoleBindingPrefix + ShortHash16(b.Kind+"/"+b.Namespace+"/"+b.Name+"/"+b.Role)
+}
+
+// InTenantRoleCatalog reports whether role is listed in the tenant's baseline.rbac.roleCatalog.
+func InTenantRoleCatalog(spec *TenantSpec, role string) bool {
+	if spec.Baseline == nil || spec.Baseline.RBAC == nil {
+		return false
+	}
+	for _, r := range spec.Baseline.RBAC.RoleCatalog {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,86 @@
+package v1alpha1
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/yaml"
+)
+
+// manifestPlaceholders maps each template placeholder to a plain YAML token. Templates are parsed with
+// the tokens in place and the values are substituted into the parsed strings, so a value containing
+// ":", "#" or a newline cannot change the structure of the object.
+var manifestPlaceholders = [][2]string{
+	{"{tenant}", "__guardian_tenant__"},
+	{"{env}", "__guardian_env__"},
+	{"{namespace}", "__guardian_namespace__"},
+	{"{ownerGroup}", "__guardian_ownergroup__"},
+}
+
+// RenderManifest parses a BaselineManifest template and substitutes {tenant}, {env}, {namespace} and
+// {ownerGroup} in its string keys and values.
+func RenderManifest(tmpl, tenant, env, namespace, ownerGroup string) (*unstructured.Unstructured, error) {
+	var toToken, toValue []string
+	for i, v := range []string{tenant, env, namespace, ownerGroup} {
+		ph := manifestPlaceholders[i]
+		toToken = append(toToken, ph[0], ph[1])
+		toValue = append(toValue, ph[1], v)
+	}
+	obj, err := ParseManifest(strings.NewReplacer(toToken...).Replace(tmpl))
+	if err != nil {
+		return nil, err
+	}
+	obj.Object = substituteStrings(obj.Object, strings.NewReplacer(toValue...)).(map[string]interface{})
+	return obj, nil
+}
+
+func substituteStrings(v interface{}, r *strings.Replacer) interface{} {
+	switch t := v.(type) {
+	case string:
+		return r.Replace(t)
+	case map[string]interface{}:
+		out := make(map[string]interface{}, len(t))
+		for k, x := range t {
+			out[r.Replace(k)] = substituteStrings(x, r)
+		}
+		return out
+	case []interface{}:
+		for i, x := range t {
+			t[i] = substituteStrings(x, r)
+		}
+		return t
+	}
+	return v
+}
+
+// ParseManifest decodes a rendered template into a single object with apiVersion, kind and metadata.name.
+func ParseManifest(rendered string) (*unstructured.Unstructured, error) {
+	obj := &unstructured.Unstructured{}
+	if err := yaml.Unmarshal([]byte(rendered), &obj.Object); err != nil {
+		return nil, fmt.Errorf("invalid YAML: %w", err)
+	}
+	if obj.Object == nil {
+		return nil, fmt.Errorf("template is empty")
+	}
+	if obj.GetAPIVersion() == "" || obj.GetKind() == "" {
+		return nil, fmt.Errorf("apiVersion and kind are required")
+	}
+	if obj.GetName() == "" {
+		return nil, fmt.Errorf("metadata.name is required")
+	}
+	return obj, nil
+}
+
+// ManifestApplies reports whether m is applied for env.
+func ManifestApplies(m BaselineManifest, env string) bool {
+	if len(m.Envs) == 0 {
+		return true
+	}
+	for _, e := range m.Envs {
+		if e == env {
+			return true
+		}
+	}
+	return false
+}
@@ -17,19 +17,171 @@ type NamespaceRequestSpec struct {
 	Env string `json:"env,omitempty"`
 
 	// OwnerGroup 必填：申请主体所在的组（用于“一组一个 ns”的唯一性约束）
+	// 只能通过 guardian.io/transfer-to + guardian.io/transfer-accept 移交，不能直接修改
 	// +kubebuilder:validation:MinLength=1
 	// +kubebuilder:validation:MaxLength=128
 	OwnerGroup string `json:"ownerGroup"`
+
+	// NamespaceName 可选：Tenant naming.strategy=Requested 时按此名称创建；
+	// 为空时由 defaulting webhook 按 Tenant 命名模板计算并回填，创建后不可修改
+	// +kubebuilder:validation:MaxLength=63
+	// +optional
+	NamespaceName string `json:"namespaceName,omitempty"`
+
+	// Size 可选：Tenant baseline.quota.sizes[env] 里提供的配额规格（如 small/medium/large），
+	// 可修改，修改后只调整 guardian-rq-default，不重建 namespace
+	// +kubebuilder:validation:MaxLength=63
+	// +optional
+	Size string `json:"size,omitempty"`
+
+	// Adopt 可选：接管一个已存在、未受管理的 namespace（需 Tenant allowAdoption 且申请人属于 <tenant>:ns-admin）；
+	// controller 先 dry-run 出 baseline 变更写到 status.adoption，确认后才真正接管，创建后不可修改
+	// +kubebuilder:validation:MaxLength=63
+	// +optional
+	Adopt string `json:"adopt,omitempty"`
+
+	// ExtraBindings 可选：owner/admin 之外的额外 RoleBinding（如邻组只读、CI ServiceAccount），可修改；
+	// role 须在 Tenant baseline.rbac.roleCatalog 中或带 guardian.io/role-catalog=true 标签，subject 须在 Tenant allowedGroups 范围内
+	// +kubebuilder:validation:MaxItems=32
+	// +optional
+	ExtraBindings []ExtraBinding `json:"extraBindings,omitempty"`
+
+	// TTL 可选：从创建时间算起的存活时长，到期后 request 与 namespace 一起删除；
+	// 上限由 Tenant ttl.maxByEnv 约束，延长用 guardian.io/extend-ttl 注解
+	// +optional
+	TTL *metav1.Duration `json:"ttl,omitempty"`
+}
+
+// ExtraBinding：subject -> ClusterRole 的额外绑定
+type ExtraBinding struct {
+	// Kind：Group 或 ServiceAccount
+	// +kubebuilder:validation:Enum=Group;ServiceAccount
+	Kind string `json:"kind"`
+
+	// Name：group 名或 ServiceAccount 名
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=253
+	Name string `json:"name"`
+
+	// Namespace：ServiceAccount 所在 namespace，为空表示本 namespace；Group 不填
+	// +kubebuilder:validation:MaxLength=63
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+
+	// Role：绑定的 ClusterRole
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=253
+	Role string `json:"role"`
 }
 
 type NamespaceRequestPhase string
 
 const (
-	PhasePending     NamespaceRequestPhase = "Pending"
-	PhaseProvisioned NamespaceRequestPhase = "Provisioned"
-	PhaseFailed      NamespaceRequestPhase = "Failed"
+	PhasePending          NamespaceRequestPhase = "Pending"
+	PhaseAwaitingApproval NamespaceRequestPhase = "AwaitingApproval"
+	PhaseAdoptionPending  NamespaceRequestPhase = "AdoptionPending"
+	PhaseProvisioned      NamespaceRequestPhase = "Provisioned"
+	PhaseRetrying         NamespaceRequestPhase = "Retrying" // 临时性失败，按退避时间自动重试
+	PhaseFailed           NamespaceRequestPhase = "Failed"
+	PhaseSuspended        NamespaceRequestPhase = "Suspended"
+	PhaseTerminating      NamespaceRequestPhase = "Terminating"
+)
+
+// NamespaceRequest condition types：一个 baseline 组件一个 condition，方便定位是哪一步失败
+const (
+	CondTenantValid          = "TenantValid"
+	CondApproved             = "Approved"
+	CondNamespaceReady       = "NamespaceReady"
+	CondRBACReady            = "RBACReady"
+	CondQuotaReady           = "QuotaReady"
+	CondLimitRangeReady      = "LimitRangeReady"
+	CondNetworkPolicyReady   = "NetworkPolicyReady"
+	CondServiceAccountsReady = "ServiceAccountsReady"
+	CondPropagationReady     = "PropagationReady"
+	CondManifestsReady       = "ManifestsReady"
+	CondBaselineVersionReady = "BaselineVersionReady" // 旧版本 baseline 遗留对象已清理，namespace 已记录新版本
+
+	// CondExpiring 为 True 表示即将/已经到期（反向语义：True 是需要关注的状态）
+	CondExpiring = "Expiring"
+)
+
+// 接管 dry-run 中对 baseline 对象的动作
+const (
+	BaselineActionCreate    = "Create"
+	BaselineActionOverwrite = "Overwrite"
+	BaselineActionReplace   = "Replace" // roleRef 变化等不可原地修改的字段：删除后重建
 )
 
+// BaselineObjectChange：dry-run 得出的单个对象变更
+type BaselineObjectChange struct {
+	Kind   string `json:"kind"`
+	Name   string `json:"name"`
+	Action string `json:"action"`
+}
+
+// AdoptionStatus：接管已有 namespace 前的 dry-run 结果
+type AdoptionStatus struct {
+	// Plan：接管时将被创建/覆盖的 baseline 对象（namespace 本身会被打上 guardian 标签）
+	// +optional
+	Plan []BaselineObjectChange `json:"plan,omitempty"`
+
+	// PlanHash：确认方式 kubectl annotate nsreq <name> guardian.io/adopt-confirm=<planHash>；
+	// plan 变化后 hash 随之变化，需要重新确认
+	// +optional
+	PlanHash string `json:"planHash,omitempty"`
+
+	// Adopted：已确认并完成接管
+	// +optional
+	Adopted bool `json:"adopted,omitempty"`
+}
+
+// OwnerTransfer：一次 ownerGroup 移交记录
+type OwnerTransfer struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+
+	// +optional
+	RequestedBy string `json:"requestedBy,omitempty"`
+	// +optional
+	AcceptedBy string `json:"acceptedBy,omitempty"`
+
+	Time metav1.Time `json:"time"`
+}
+
+// ManifestStatus：Tenant baseline.manifests 中单个对象的下发结果
+type ManifestStatus struct {
+	// Name：Tenant baseline.manifests[].name
+	Name string `json:"name"`
+
+	// +optional
+	APIVersion string `json:"apiVersion,omitempty"`
+	// +optional
+	Kind string `json:"kind,omitempty"`
+	// +optional
+	ObjectName string `json:"objectName,omitempty"`
+
+	// Applied：对象已按模板创建/更新
+	Applied bool `json:"applied"`
+
+	// Message：失败原因
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// RetryStatus：临时性失败（API 超时、冲突等）的退避重试状态
+type RetryStatus struct {
+	// Attempts：连续失败次数，成功或终态失败后清空
+	Attempts int32 `json:"attempts"`
+
+	// NextRetryAt：下一次自动重试的时间
+	// +optional
+	NextRetryAt *metav1.Time `json:"nextRetryAt,omitempty"`
+
+	// LastError：最近一次失败的错误
+	// +optional
+	LastError string `json:"lastError,omitempty"`
+}
+
 // NamespaceRequestStatus：系统回写状态
 type NamespaceRequestStatus struct {
 	Phase NamespaceRequestPhase `json:"phase,omitempty"`
@@ -37,6 +189,62 @@ type NamespaceRequestStatus struct {
 	// NamespaceName：最终创建出的 namespace 名称
 	NamespaceName string `json:"namespaceName,omitempty"`
 
+	// ObservedTenantGeneration：最近一次下发 baseline 时 Tenant 的 generation
+	// Tenant spec 变化后该值落后，controller 会重新下发
+	// +optional
+	ObservedTenantGeneration int64 `json:"observedTenantGeneration,omitempty"`
+
+	// ObservedTenantSpecHash：最近一次下发 baseline 时 Tenant spec.baseline 的 hash，rollout 按它判断是否已更新
+	// +optional
+	ObservedTenantSpecHash string `json:"observedTenantSpecHash,omitempty"`
+
+	// ObservedGeneration：status 对应的 NamespaceRequest generation，落后说明 status 已过期
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// AppliedGeneration：最近一次成功下发 baseline 时 NamespaceRequest 的 generation
+	// 落后说明 request 自身 spec（ownerGroup / size / extraBindings 等）的变化还没下发；
+	// 与 ObservedGeneration 不同，Retrying / AwaitingApproval / RolloutPending 等中间状态不会更新它
+	// +optional
+	AppliedGeneration int64 `json:"appliedGeneration,omitempty"`
+
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// OwnerGroup：当前 baseline 实际绑定的 ownerGroup，与 spec 不一致说明正在移交
+	// +optional
+	OwnerGroup string `json:"ownerGroup,omitempty"`
+
+	// OwnerHistory：ownerGroup 移交记录（保留最近 10 条）
+	// +optional
+	OwnerHistory []OwnerTransfer `json:"ownerHistory,omitempty"`
+
+	// Adoption：spec.adopt 的 dry-run 结果与确认状态
+	// +optional
+	Adoption *AdoptionStatus `json:"adoption,omitempty"`
+
+	// ServiceAccounts：baseline 在 namespace 里创建的 ServiceAccount（Tenant baseline.serviceAccounts），供流水线发现
+	// +optional
+	ServiceAccounts []string `json:"serviceAccounts,omitempty"`
+
+	// Manifests：Tenant baseline.manifests 逐个对象的下发结果，也用于清理从 Tenant 中移除的对象
+	// +optional
+	Manifests []ManifestStatus `json:"manifests,omitempty"`
+
+	// Retry：phase=Retrying 时的重试次数与下一次重试时间
+	// +optional
+	Retry *RetryStatus `json:"retry,omitempty"`
+
+	// ExpiresAt：creationTimestamp + spec.ttl，未设置 ttl 时为空
+	// +optional
+	ExpiresAt *metav1.Time `json:"expiresAt,omitempty"`
+
+	// DriftCorrected：Provisioned 之后被修复的 baseline 对象累计次数（对象被改/被删后恢复）
+	// +optional
+	DriftCorrected int32 `json:"driftCorrected,omitempty"`
+
 	// Reason/Message：失败原因（阶段1先留接口）
 	Reason  string `json:"reason,omitempty"`
 	Message string `json:"message,omitempty"`
@@ -45,6 +253,15 @@ type NamespaceRequestStatus struct {
 // +kubebuilder:object:root=true
 // +kubebuilder:resource:scope=Cluster,shortName=nsreq
 // +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Tenant",type=string,JSONPath=`.spec.tenant`
+// +kubebuilder:printcolumn:name="Env",type=string,JSONPath=`.spec.env`
+// +kubebuilder:printcolumn:name="Phase",type=string,JSONPath=`.status.phase`
+// +kubebuilder:printcolumn:name="Namespace",type=string,JSONPath=`.status.namespaceName`
+// +kubebuilder:printcolumn:name="Size",type=string,JSONPath=`.spec.size`,priority=1
+// +kubebuilder:printcolumn:name="Retries",type=integer,JSONPath=`.status.retry.attempts`,priority=1
+// +kubebuilder:printcolumn:name="Expires",type=date,JSONPath=`.status.expiresAt`
+// +kubebuilder:printcolumn:name="Reason",type=string,JSONPath=`.status.reason`,priority=1
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
 type NamespaceRequest struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
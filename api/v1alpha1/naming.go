@@ -0,0 +1,117 @@
+package v1alpha1
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+var (
+	dns1123Re         = regexp.MustCompile(`[^a-z0-9-]+`)
+	namingPlaceholder = regexp.MustCompile(`\{[^{}]*\}`)
+	namingVars        = []string{"{tenant}", "{env}", "{group}", "{hash}"}
+)
+
+// NamespaceNameFor returns the namespace name a request should get under the tenant naming strategy:
+// spec.adopt for adoptions, spec.namespaceName when set (the defaulting webhook fills it in),
+// otherwise the rendered template.
+func NamespaceNameFor(spec *TenantSpec, nr *NamespaceRequest) string {
+	if n := strings.TrimSpace(nr.Spec.Adopt); n != "" {
+		return n
+	}
+	if n := strings.TrimSpace(nr.Spec.NamespaceName); n != "" {
+		return n
+	}
+	return RenderNamespaceName(spec, nr.Spec.Tenant, requestEnv(nr), nr.Spec.OwnerGroup)
+}
+
+// RenderNamespaceName renders the tenant naming template. Names longer than 63 characters are
+// cut and suffixed with the ownerGroup hash so that two long names do not collide after truncation.
+func RenderNamespaceName(spec *TenantSpec, tenant, env, ownerGroup string) string {
+	tmpl := DefaultNamespaceNameTemplate
+	if spec != nil && spec.Naming != nil && strings.TrimSpace(spec.Naming.Template) != "" {
+		tmpl = strings.TrimSpace(spec.Naming.Template)
+	}
+	tenant, ownerGroup = strings.TrimSpace(tenant), strings.TrimSpace(ownerGroup)
+	hash := ShortHash16(ownerGroup)[:8]
+
+	raw := strings.NewReplacer(
+		"{tenant}", tenant,
+		"{env}", env,
+		"{group}", strings.TrimPrefix(ownerGroup, tenant+":"),
+		"{hash}", hash,
+	).Replace(tmpl)
+
+	full := strings.Trim(dns1123Re.ReplaceAllString(strings.ToLower(strings.TrimSpace(raw)), "-"), "-")
+	if len(full) > 63 {
+		return strings.Trim(full[:54], "-") + "-" + hash
+	}
+	return SanitizeDNS1123(full)
+}
+
+// ValidateNamespaceName checks a generated or requested name: DNS-1123 label and namespaceNamePattern.
+func ValidateNamespaceName(spec *TenantSpec, name string, fldPath *field.Path) field.ErrorList {
+	var errs field.ErrorList
+	for _, msg := range validation.IsDNS1123Label(name) {
+		errs = append(errs, field.Invalid(fldPath, name, msg))
+	}
+	if p := strings.TrimSpace(spec.NamespaceNamePattern); p != "" {
+		re, err := regexp.Compile(p)
+		if err != nil {
+			errs = append(errs, field.Invalid(fldPath, name, fmt.Sprintf("tenant namespaceNamePattern does not compile: %v", err)))
+		} else if !re.MatchString(name) {
+			errs = append(errs, field.Invalid(fldPath, name, fmt.Sprintf("does not match tenant namespaceNamePattern %q", p)))
+		}
+	}
+	return errs
+}
+
+// AllowsRequestedNamespaceName reports whether the tenant lets users pick their own namespace name.
+func AllowsRequestedNamespaceName(spec *TenantSpec) bool {
+	return spec.Naming != nil && spec.Naming.Strategy == NamingStrategyRequested
+}
+
+// SanitizeDNS1123 turns s into a DNS-1123 label: lowercase alphanum or '-', start/end alphanum, max 63.
+func SanitizeDNS1123(s string) string {
+	s = strings.ToLower(strings.TrimSpace(s))
+	s = dns1123Re.ReplaceAllString(s, "-")
+	s = strings.Trim(s, "-")
+	if s == "" {
+		return "ns"
+	}
+	if len(s) > 63 {
+		s = s[:63]
+		s = strings.Trim(s, "-")
+		if s == "" {
+			return "ns"
+		}
+	}
+	return s
+}
+
+func validateNamingTemplate(tmpl string, fldPath *field.Path) field.ErrorList {
+	var errs field.ErrorList
+	for _, ph := range namingPlaceholder.FindAllString(tmpl, -1) {
+		known := false
+		for _, v := range namingVars {
+			if ph == v {
+				known = true
+				break
+			}
+		}
+		if !known {
+			errs = append(errs, field.Invalid(fldPath, tmpl, fmt.Sprintf("unknown placeholder %s, supported: %s", ph, strings.Join(namingVars, ", "))))
+		}
+	}
+	return errs
+}
+
+func requestEnv(nr *NamespaceRequest) string {
+	if env := strings.TrimSpace(nr.Spec.Env); env != "" {
+		return env
+	}
+	return EnvDev
+}
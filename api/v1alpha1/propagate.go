@@ -0,0 +1,12 @@
+package v1alpha1
+
+import "strings"
+
+// SplitObjectRef splits a <namespace>/<name> reference used by TenantPropagateSpec.
+func SplitObjectRef(ref string) (namespace, name string, ok bool) {
+	namespace, name, ok = strings.Cut(strings.TrimSpace(ref), "/")
+	if !ok || namespace == "" || name == "" || strings.Contains(name, "/") {
+		return "", "", false
+	}
+	return namespace, name, true
+}
@@ -0,0 +1,18 @@
+package v1alpha1
+
+// QuotaSize returns the named quota size the tenant offers for env.
+func QuotaSize(spec *TenantSpec, env, size string) (QuotaHard, bool) {
+	if spec.Baseline == nil || spec.Baseline.Quota == nil {
+		return QuotaHard{}, false
+	}
+	q, ok := spec.Baseline.Quota.Sizes[env][size]
+	return q, ok
+}
+
+// QuotaSizeNames lists the sizes offered for env in stable order (for error messages).
+func QuotaSizeNames(spec *TenantSpec, env string) []string {
+	if spec.Baseline == nil || spec.Baseline.Quota == nil {
+		return nil
+	}
+	return sortedKeys(spec.Baseline.Quota.Sizes[env])
+}
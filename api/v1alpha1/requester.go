@@ -0,0 +1,17 @@
+package v1alpha1
+
+// RequesterAnnotationKeys are stamped by the mutating webhook from the admission request on create
+// and are immutable afterwards.
+var RequesterAnnotationKeys = []string{AnnRequestedBy, AnnRequestedByGroups, AnnRequestUID, AnnRequestedAt}
+
+// RequesterAnnotations returns the requester identity annotations present on nr,
+// copied onto the namespace and baseline objects for audit.
+func RequesterAnnotations(nr *NamespaceRequest) map[string]string {
+	out := map[string]string{}
+	for _, k := range RequesterAnnotationKeys {
+		if v, ok := nr.Annotations[k]; ok {
+			out[k] = v
+		}
+	}
+	return out
+}
@@ -0,0 +1,26 @@
+package v1alpha1
+
+import (
+	"encoding/json"
+	"slices"
+)
+
+// RolloutStages returns the env order of a rollout: the configured stages, then the remaining envs.
+func RolloutStages(r *TenantRolloutSpec) []string {
+	out := slices.Clone(r.Stages)
+	for _, env := range ValidEnvs {
+		if !slices.Contains(out, env) {
+			out = append(out, env)
+		}
+	}
+	return out
+}
+
+// RolloutSpecHash hashes spec.baseline, the only part of the Tenant spec that renders baseline objects
+// (RBAC, quota, limit range, network policy, ServiceAccounts, propagation, manifests and the version).
+// Rollout progress is keyed by it, so changing spec.rollout, suspend, allowedGroups, approval, TTL or
+// naming (which bumps the generation) neither starts nor restarts a rollout.
+func RolloutSpecHash(spec *TenantSpec) string {
+	b, _ := json.Marshal(spec.Baseline)
+	return ShortHash16(string(b))
+}
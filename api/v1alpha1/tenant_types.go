@@ -10,6 +10,7 @@ const (
 	EnvProd = "prod"
 
 	BaselineVersionV1 = "v1"
+	BaselineVersionV2 = "v2" // tenant admin RoleBinding renamed to guardian-tenant-admin
 
 	NPProfileStandard = "standard" // deny-all + allow-dns + allow-same-namespace
 	NPProfileStrict   = "strict"   // deny-all + allow-dns
@@ -18,8 +19,35 @@ const (
 	DefaultOwnerClusterRole = "guardian-tenant-edit"
 	DefaultAdminClusterRole = "guardian-tenant-admin"
 
+	RolloutProgressing = "Progressing"
+	RolloutSoaking     = "Soaking"
+	RolloutPaused      = "Paused"
+	RolloutHalted      = "Halted" // an admitted namespace reported BaselineFailed
+	RolloutComplete    = "Complete"
+
+	DeletionPolicyBlock  = "Block"  // refuse deletion while namespaces/requests exist
+	DeletionPolicyOrphan = "Orphan" // strip guardian.io/managed and leave namespaces behind
+	DeletionPolicyDelete = "Delete" // delete requests, then namespaces
+
+	ReclaimPolicyDelete = "Delete" // delete the namespace together with its NamespaceRequest
+	ReclaimPolicyRetain = "Retain" // keep the namespace, relabel it as orphaned
+
+	ExtraBindingKindGroup          = "Group"
+	ExtraBindingKindServiceAccount = "ServiceAccount"
+
+	// Force takes conflicting fields over (reported in the component condition);
+	// Report leaves them to the other manager and marks the component not ready.
+	ConflictPolicyForce  = "Force"
+	ConflictPolicyReport = "Report"
+
+	NamingStrategyTemplate  = "Template"  // namespace name always rendered from naming.template
+	NamingStrategyRequested = "Requested" // NamespaceRequest.spec.namespaceName wins, template is the fallback
+
+	DefaultNamespaceNameTemplate = "{tenant}-{env}-{group}"
+
 	CondValid           = "Valid"
 	CondBaselineApplied = "BaselineApplied"
+	CondDeleting        = "Deleting"
 )
 
 type TenantSpec struct {
@@ -38,23 +66,154 @@ type TenantSpec struct {
 
 	// Suspend stops applying/updating baseline for this tenant (emergency brake).
 	// Webhook may still allow/deny NamespaceRequest, but controller should skip baseline reconcile when suspended.
+	// Destructive paths are paused as well: TTL reclaim, namespace reclaim on request deletion and Tenant deletion
+	// wait until suspend is cleared.
 	// +optional
 	Suspend bool `json:"suspend,omitempty"`
 
+	// SuspendDenyCreate makes the admission webhook reject new NamespaceRequests while Suspend is true.
+	// +optional
+	SuspendDenyCreate bool `json:"suspendDenyCreate,omitempty"`
+
 	// NamespaceNamePattern optionally constrains generated namespace names for this tenant.
 	// Example: ^tenant-a-(dev|test|prod)-[a-z0-9]([-a-z0-9]*[a-z0-9])?$
 	// +optional
 	NamespaceNamePattern string `json:"namespaceNamePattern,omitempty"`
 
+	// AllowAdoption lets tenant admins adopt pre-existing unmanaged namespaces via NamespaceRequest.spec.adopt.
+	// +optional
+	AllowAdoption bool `json:"allowAdoption,omitempty"`
+
+	// Naming decides how namespace names are generated for NamespaceRequests of this tenant.
+	// Generated and requested names are both checked against NamespaceNamePattern.
+	// +optional
+	Naming *TenantNamingSpec `json:"naming,omitempty"`
+
 	// Baseline defines RBAC/Quota/LimitRange/NetworkPolicy defaults and per-env overrides.
 	// +optional
 	Baseline *TenantBaselineSpec `json:"baseline,omitempty"`
+
+	// Rollout staggers spec.baseline changes across already provisioned namespaces
+	// (env by env, rate limited, halted on failures). Unset applies changes to all namespaces at once.
+	// +optional
+	Rollout *TenantRolloutSpec `json:"rollout,omitempty"`
+
+	// Approval requires approvers before NamespaceRequests of the listed envs are provisioned.
+	// +optional
+	Approval *TenantApprovalSpec `json:"approval,omitempty"`
+
+	// TTL bounds NamespaceRequest.spec.ttl per env and controls the expiry warning.
+	// +optional
+	TTL *TenantTTLSpec `json:"ttl,omitempty"`
+
+	// Reclaim decides what happens to a namespace when its NamespaceRequest is deleted.
+	// Defaults to Retain (namespace kept and labeled orphaned) when unset.
+	// +optional
+	Reclaim *TenantReclaimSpec `json:"reclaim,omitempty"`
+
+	// DeletionPolicy decides what happens to managed namespaces when the Tenant is deleted.
+	// +kubebuilder:default:=Block
+	// +kubebuilder:validation:Enum=Block;Orphan;Delete
+	// +optional
+	DeletionPolicy string `json:"deletionPolicy,omitempty"`
+}
+
+type TenantRolloutSpec struct {
+	// Stages orders the envs: every namespace of a stage is updated before the next stage starts.
+	// Envs not listed go last, in dev/test/prod order. Defaults to dev, test, prod.
+	// +optional
+	Stages []string `json:"stages,omitempty"`
+
+	// SoakPeriod waits this long after a stage completes before starting the next one.
+	// +optional
+	SoakPeriod *metav1.Duration `json:"soakPeriod,omitempty"`
+
+	// MaxPerMinute caps how many namespaces start updating per minute. 0 means no limit.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MaxPerMinute int32 `json:"maxPerMinute,omitempty"`
+
+	// Paused stops admitting namespaces into the rollout; namespaces already admitted finish.
+	// +optional
+	Paused bool `json:"paused,omitempty"`
+}
+
+type TenantApprovalSpec struct {
+	// Envs lists the envs whose NamespaceRequests wait in AwaitingApproval, e.g. [prod].
+	// +optional
+	Envs []string `json:"envs,omitempty"`
+
+	// ApproverGroups may approve requests. Defaults to <tenant>:ns-admin.
+	// +optional
+	ApproverGroups []string `json:"approverGroups,omitempty"`
+
+	// RequiredApprovals is the number of distinct approvers needed.
+	// +kubebuilder:default:=1
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	RequiredApprovals int32 `json:"requiredApprovals,omitempty"`
+
+	// AllowSelfApproval lets the requester count as one of the approvers.
+	// +optional
+	AllowSelfApproval bool `json:"allowSelfApproval,omitempty"`
+}
+
+type TenantTTLSpec struct {
+	// MaxByEnv caps the remaining lifetime of a request per env, checked on create and on every extension.
+	// Envs without an entry have no cap.
+	// +optional
+	MaxByEnv map[string]metav1.Duration `json:"maxByEnv,omitempty"`
+
+	// DefaultByEnv is applied by the defaulting webhook when spec.ttl is empty.
+	// +optional
+	DefaultByEnv map[string]metav1.Duration `json:"defaultByEnv,omitempty"`
+
+	// WarnBefore sets how long before expiry the Expiring condition and event fire. Defaults to 24h.
+	// +optional
+	WarnBefore *metav1.Duration `json:"warnBefore,omitempty"`
+}
+
+type TenantNamingSpec struct {
+	// Strategy is Template (always generate) or Requested (honor spec.namespaceName, else generate).
+	// +kubebuilder:default:=Template
+	// +kubebuilder:validation:Enum=Template;Requested
+	// +optional
+	Strategy string `json:"strategy,omitempty"`
+
+	// Template supports {tenant}, {env}, {group} (ownerGroup slug without the "<tenant>:" prefix)
+	// and {hash} (8 chars of the ownerGroup hash). Defaults to {tenant}-{env}-{group}.
+	// +kubebuilder:validation:MaxLength=128
+	// +optional
+	Template string `json:"template,omitempty"`
+}
+
+type TenantReclaimSpec struct {
+	// Default reclaim policy (fallback for all env).
+	// +optional
+	Default ReclaimPolicy `json:"default,omitempty"`
+
+	// ByEnv overrides reclaim policy per env (dev/test/prod).
+	// +optional
+	ByEnv map[string]ReclaimPolicy `json:"byEnv,omitempty"`
+}
+
+type ReclaimPolicy struct {
+	// +kubebuilder:validation:Enum=Delete;Retain
+	// +optional
+	Policy string `json:"policy,omitempty"`
+
+	// RetainGracePeriod deletes a retained (orphaned) namespace after this duration.
+	// Empty keeps it until someone re-requests or removes it.
+	// +optional
+	RetainGracePeriod *metav1.Duration `json:"retainGracePeriod,omitempty"`
 }
 
 type TenantBaselineSpec struct {
-	// Version is used for baseline resource versioning and future upgrades.
+	// Version selects the baseline renderer. Raising it migrates every namespace one version at a time:
+	// objects of the new version are applied first, then objects the old version no longer needs are deleted.
+	// Lowering it is rejected. Empty means v1.
 	// +kubebuilder:default:=v1
-	// +kubebuilder:validation:Enum=v1
+	// +kubebuilder:validation:Enum=v1;v2
 	Version string `json:"version,omitempty"`
 
 	// RBAC configures which ClusterRoles are bound into namespaces.
@@ -72,6 +231,95 @@ type TenantBaselineSpec struct {
 	// NetworkPolicy defines namespace isolation baseline and per-env overrides.
 	// +optional
 	NetworkPolicy *TenantNetworkPolicySpec `json:"networkPolicy,omitempty"`
+
+	// ServiceAccounts declares ServiceAccounts (e.g. CI deployers) created in every namespace, per env.
+	// +optional
+	ServiceAccounts *TenantServiceAccountSpec `json:"serviceAccounts,omitempty"`
+
+	// Propagate copies Secrets/ConfigMaps from source namespaces into every managed namespace and keeps them in sync.
+	// Sources must be labeled guardian.io/propagation=source; existing objects not created by guardian are never overwritten.
+	// +optional
+	Propagate *TenantPropagateSpec `json:"propagate,omitempty"`
+
+	// Manifests are extra namespaced objects (e.g. PodDisruptionBudget, Role, Istio Sidecar) applied into every namespace.
+	// The manager ServiceAccount must be granted access to their kinds.
+	// +kubebuilder:validation:MaxItems=32
+	// +optional
+	Manifests []BaselineManifest `json:"manifests,omitempty"`
+
+	// Conflicts decides what happens when another field manager owns a field the baseline sets
+	// (baseline objects are written with server-side apply as "namespace-guardian").
+	// +optional
+	Conflicts *TenantConflictSpec `json:"conflicts,omitempty"`
+}
+
+type TenantConflictSpec struct {
+	// Default applies to components without an override. Defaults to Force.
+	// +kubebuilder:validation:Enum=Force;Report
+	// +optional
+	Default string `json:"default,omitempty"`
+
+	// ByComponent overrides the policy per component:
+	// RBAC, ServiceAccounts, Propagation, Quota, LimitRange, NetworkPolicy, Manifests.
+	// +optional
+	ByComponent map[string]string `json:"byComponent,omitempty"`
+}
+
+type BaselineManifest struct {
+	// Name identifies the manifest in NamespaceRequest status.manifests; unique per tenant.
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=63
+	Name string `json:"name"`
+
+	// Envs limits the manifest to these envs; empty applies to all envs.
+	// +optional
+	Envs []string `json:"envs,omitempty"`
+
+	// Template is a single namespaced object in YAML. {tenant}, {env}, {namespace} and {ownerGroup}
+	// are substituted into the parsed string keys and values; metadata.namespace is always the managed namespace.
+	// The controller needs RBAC for the kind (config/rbac/manifest_objects.yaml) and watches it for drift.
+	// +kubebuilder:validation:MinLength=1
+	Template string `json:"template"`
+}
+
+type TenantPropagateSpec struct {
+	// Secrets to copy, as <namespace>/<name> (e.g. platform/regcred). The copy keeps the source name.
+	// +kubebuilder:validation:items:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?/[a-z0-9]([-.a-z0-9]*[a-z0-9])?$`
+	// +optional
+	Secrets []string `json:"secrets,omitempty"`
+
+	// ConfigMaps to copy, as <namespace>/<name> (e.g. platform/ca-bundle). The copy keeps the source name.
+	// +kubebuilder:validation:items:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?/[a-z0-9]([-.a-z0-9]*[a-z0-9])?$`
+	// +optional
+	ConfigMaps []string `json:"configMaps,omitempty"`
+}
+
+type TenantServiceAccountSpec struct {
+	// Default ServiceAccounts are created for all envs.
+	// +optional
+	Default []BaselineServiceAccount `json:"default,omitempty"`
+
+	// ByEnv adds ServiceAccounts per env (dev/test/prod); an entry with the same name replaces the default one.
+	// +optional
+	ByEnv map[string][]BaselineServiceAccount `json:"byEnv,omitempty"`
+}
+
+type BaselineServiceAccount struct {
+	// Name of the ServiceAccount. Must not be default; an existing ServiceAccount the request did not create
+	// is never taken over (the request fails instead).
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=63
+	Name string `json:"name"`
+
+	// ClusterRole is bound to the ServiceAccount inside its namespace (RoleBinding guardian-sa-<name>).
+	// It must be the owner or admin ClusterRole or listed in baseline.rbac.roleCatalog.
+	// Empty creates the ServiceAccount without a binding.
+	// +optional
+	ClusterRole string `json:"clusterRole,omitempty"`
+
+	// ImagePullSecrets are referenced by the ServiceAccount; the Secrets must exist in the namespace.
+	// +optional
+	ImagePullSecrets []string `json:"imagePullSecrets,omitempty"`
 }
 
 type TenantRBACSpec struct {
@@ -84,6 +332,11 @@ type TenantRBACSpec struct {
 	// +kubebuilder:default:=guardian-tenant-admin
 	// +kubebuilder:validation:MinLength=1
 	AdminClusterRole string `json:"adminClusterRole,omitempty"`
+
+	// RoleCatalog lists the ClusterRoles NamespaceRequest.spec.extraBindings may reference for this tenant,
+	// in addition to ClusterRoles labeled guardian.io/role-catalog=true cluster-wide.
+	// +optional
+	RoleCatalog []string `json:"roleCatalog,omitempty"`
 }
 
 type TenantQuotaSpec struct {
@@ -94,6 +347,11 @@ type TenantQuotaSpec struct {
 	// ByEnv overrides quota per env (dev/test/prod).
 	// +optional
 	ByEnv map[string]QuotaHard `json:"byEnv,omitempty"`
+
+	// Sizes offers named quota sizes per env (env -> size name -> quota), e.g. small/medium/large.
+	// NamespaceRequest.spec.size picks one; it is merged on top of default -> byEnv[env] field by field.
+	// +optional
+	Sizes map[string]map[string]QuotaHard `json:"sizes,omitempty"`
 }
 
 type QuotaHard struct {
@@ -160,13 +418,75 @@ type TenantStatus struct {
 	// +optional
 	ManagedNamespaces int32 `json:"managedNamespaces,omitempty"`
 
+	// BaselineVersions counts managed namespaces by the baseline version recorded on them.
+	// +optional
+	// +listType=map
+	// +listMapKey=version
+	BaselineVersions []BaselineVersionCount `json:"baselineVersions,omitempty"`
+
+	// Rollout reports the progress of the current Tenant generation across provisioned namespaces.
 	// +optional
+	Rollout *TenantRolloutStatus `json:"rollout,omitempty"`
+
+	// +optional
+	// +listType=map
+	// +listMapKey=type
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
 
+type TenantRolloutStatus struct {
+	// Generation is the Tenant generation being rolled out.
+	Generation int64 `json:"generation"`
+
+	// SpecHash identifies the baseline being rolled out: a hash of spec.baseline.
+	// Progress is kept while it is unchanged, so pausing or resuming continues where the rollout stopped.
+	// +optional
+	SpecHash string `json:"specHash,omitempty"`
+
+	// Phase is Progressing, Soaking, Paused, Halted or Complete.
+	Phase string `json:"phase"`
+
+	// Stage is the env currently being updated (or soaking before it).
+	// +optional
+	Stage string `json:"stage,omitempty"`
+
+	// Updated / Total count provisioned namespaces already at SpecHash.
+	Updated int32 `json:"updated"`
+	Total   int32 `json:"total"`
+
+	// SoakUntil is when the current stage may start.
+	// +optional
+	SoakUntil *metav1.Time `json:"soakUntil,omitempty"`
+
+	// Admitted lists NamespaceRequests allowed to update: those still updating, plus those admitted
+	// within the last minute (for maxPerMinute).
+	// +optional
+	// +listType=map
+	// +listMapKey=request
+	Admitted []RolloutAdmission `json:"admitted,omitempty"`
+
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+type RolloutAdmission struct {
+	Request    string      `json:"request"`
+	AdmittedAt metav1.Time `json:"admittedAt"`
+}
+
+type BaselineVersionCount struct {
+	Version    string `json:"version"`
+	Namespaces int32  `json:"namespaces"`
+}
+
 // +kubebuilder:object:root=true
 // +kubebuilder:resource:scope=Cluster,shortName=ten
 // +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Namespaces",type=integer,JSONPath=`.status.managedNamespaces`
+// +kubebuilder:printcolumn:name="Valid",type=string,JSONPath=`.status.conditions[?(@.type=="Valid")].status`
+// +kubebuilder:printcolumn:name="Baseline",type=string,JSONPath=`.status.conditions[?(@.type=="BaselineApplied")].status`
+// +kubebuilder:printcolumn:name="Rollout",type=string,JSONPath=`.status.rollout.phase`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
 type Tenant struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
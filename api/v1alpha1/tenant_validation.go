@@ -0,0 +1,339 @@
+package v1alpha1
+
+import (
+	"fmt"
+	"net"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// ValidEnvs are the env names accepted as byEnv keys and NamespaceRequest.spec.env.
+var ValidEnvs = []string{EnvDev, EnvTest, EnvProd}
+
+// ValidateTenantSpec checks the parts of TenantSpec that the CRD schema cannot express
+// (quantity strings, regex, CIDR, byEnv keys). Shared by the Tenant webhook and controller.
+func ValidateTenantSpec(spec *TenantSpec, fldPath *field.Path) field.ErrorList {
+	var errs field.ErrorList
+
+	hasGroup := false
+	for _, g := range spec.AllowedGroups {
+		if strings.TrimSpace(g) != "" {
+			hasGroup = true
+			break
+		}
+	}
+	if !hasGroup {
+		errs = append(errs, field.Required(fldPath.Child("allowedGroups"), "must contain at least one non-empty group"))
+	}
+
+	if p := strings.TrimSpace(spec.NamespaceNamePattern); p != "" {
+		if _, err := regexp.Compile(p); err != nil {
+			errs = append(errs, field.Invalid(fldPath.Child("namespaceNamePattern"), p, err.Error()))
+		}
+	}
+
+	if spec.Naming != nil {
+		errs = append(errs, validateNamingTemplate(spec.Naming.Template, fldPath.Child("naming", "template"))...)
+	}
+
+	if spec.Approval != nil {
+		for i, env := range spec.Approval.Envs {
+			errs = append(errs, validateEnvKey(env, fldPath.Child("approval", "envs").Index(i))...)
+		}
+	}
+
+	if spec.Rollout != nil {
+		seen := map[string]bool{}
+		for i, env := range spec.Rollout.Stages {
+			p := fldPath.Child("rollout", "stages").Index(i)
+			errs = append(errs, validateEnvKey(env, p)...)
+			if seen[env] {
+				errs = append(errs, field.Duplicate(p, env))
+			}
+			seen[env] = true
+		}
+	}
+
+	if spec.TTL != nil {
+		errs = append(errs, validateTTL(spec.TTL, fldPath.Child("ttl"))...)
+	}
+
+	if spec.Reclaim != nil {
+		for _, env := range sortedKeys(spec.Reclaim.ByEnv) {
+			errs = append(errs, validateEnvKey(env, fldPath.Child("reclaim", "byEnv").Key(env))...)
+		}
+	}
+
+	if spec.Baseline != nil {
+		errs = append(errs, validateBaseline(spec.Baseline, fldPath.Child("baseline"))...)
+	}
+	return errs
+}
+
+// ValidateTenantUpdate checks transitions that are only invalid relative to the old spec.
+// Namespaces are never migrated back, so the baseline version cannot go down.
+func ValidateTenantUpdate(newSpec, oldSpec *TenantSpec, fldPath *field.Path) field.ErrorList {
+	var errs field.ErrorList
+	newV, oldV := BaselineVersionOf(newSpec), BaselineVersionOf(oldSpec)
+	if CompareBaselineVersions(newV, oldV) < 0 {
+		errs = append(errs, field.Forbidden(fldPath.Child("baseline", "version"),
+			fmt.Sprintf("cannot downgrade baseline from %s to %s", oldV, newV)))
+	}
+	return errs
+}
+
+func validateBaseline(b *TenantBaselineSpec, fldPath *field.Path) field.ErrorList {
+	var errs field.ErrorList
+
+	if q := b.Quota; q != nil {
+		p := fldPath.Child("quota")
+		errs = append(errs, validateQuotaHard(q.Default, p.Child("default"))...)
+		for _, env := range sortedKeys(q.ByEnv) {
+			errs = append(errs, validateEnvKey(env, p.Child("byEnv").Key(env))...)
+			errs = append(errs, validateQuotaHard(q.ByEnv[env], p.Child("byEnv").Key(env))...)
+		}
+		for _, env := range sortedKeys(q.Sizes) {
+			errs = append(errs, validateEnvKey(env, p.Child("sizes").Key(env))...)
+			for _, size := range sortedKeys(q.Sizes[env]) {
+				errs = append(errs, validateQuotaHard(q.Sizes[env][size], p.Child("sizes").Key(env).Key(size))...)
+			}
+		}
+	}
+
+	if lr := b.LimitRange; lr != nil {
+		p := fldPath.Child("limitRange")
+		errs = append(errs, validateLimitRangeHard(lr.Default, p.Child("default"))...)
+		for _, env := range sortedKeys(lr.ByEnv) {
+			errs = append(errs, validateEnvKey(env, p.Child("byEnv").Key(env))...)
+			errs = append(errs, validateLimitRangeHard(lr.ByEnv[env], p.Child("byEnv").Key(env))...)
+		}
+	}
+
+	if np := b.NetworkPolicy; np != nil {
+		p := fldPath.Child("networkPolicy")
+		errs = append(errs, validateCIDRs(np.AllowEgressCIDRs, p.Child("allowEgressCIDRs"))...)
+		for _, env := range sortedKeys(np.ByEnv) {
+			errs = append(errs, validateEnvKey(env, p.Child("byEnv").Key(env))...)
+			errs = append(errs, validateCIDRs(np.ByEnv[env].AllowEgressCIDRs, p.Child("byEnv").Key(env).Child("allowEgressCIDRs"))...)
+		}
+	}
+
+	if sa := b.ServiceAccounts; sa != nil {
+		p := fldPath.Child("serviceAccounts")
+		roles := serviceAccountRoles(b)
+		errs = append(errs, validateServiceAccounts(sa.Default, roles, p.Child("default"))...)
+		for _, env := range sortedKeys(sa.ByEnv) {
+			errs = append(errs, validateEnvKey(env, p.Child("byEnv").Key(env))...)
+			errs = append(errs, validateServiceAccounts(sa.ByEnv[env], roles, p.Child("byEnv").Key(env))...)
+		}
+	}
+
+	if pr := b.Propagate; pr != nil {
+		p := fldPath.Child("propagate")
+		errs = append(errs, validatePropagateRefs(pr.Secrets, p.Child("secrets"))...)
+		errs = append(errs, validatePropagateRefs(pr.ConfigMaps, p.Child("configMaps"))...)
+	}
+
+	errs = append(errs, validateManifests(b.Manifests, fldPath.Child("manifests"))...)
+
+	if cf := b.Conflicts; cf != nil {
+		p := fldPath.Child("conflicts", "byComponent")
+		for _, comp := range sortedKeys(cf.ByComponent) {
+			if !slices.Contains(BaselineComponents, comp) {
+				errs = append(errs, field.NotSupported(p, comp, BaselineComponents))
+			}
+			if v := cf.ByComponent[comp]; v != ConflictPolicyForce && v != ConflictPolicyReport {
+				errs = append(errs, field.NotSupported(p.Key(comp), v, []string{ConflictPolicyForce, ConflictPolicyReport}))
+			}
+		}
+	}
+
+	return errs
+}
+
+// validateManifests：用占位值渲染一遍模板，检查是单个带 apiVersion/kind/metadata.name 的对象
+func validateManifests(ms []BaselineManifest, fldPath *field.Path) field.ErrorList {
+	var errs field.ErrorList
+	seen := map[string]bool{}
+	for i, m := range ms {
+		p := fldPath.Index(i)
+		for _, msg := range validation.IsDNS1123Label(m.Name) {
+			errs = append(errs, field.Invalid(p.Child("name"), m.Name, msg))
+		}
+		if seen[m.Name] {
+			errs = append(errs, field.Duplicate(p.Child("name"), m.Name))
+		}
+		seen[m.Name] = true
+		for j, env := range m.Envs {
+			errs = append(errs, validateEnvKey(env, p.Child("envs").Index(j))...)
+		}
+		if _, err := RenderManifest(m.Template, "tenant", EnvDev, "tenant-dev", "tenant:dev"); err != nil {
+			errs = append(errs, field.Invalid(p.Child("template"), m.Name, err.Error()))
+		}
+	}
+	return errs
+}
+
+// validatePropagateRefs：格式由 CRD pattern 保证，这里只检查复制后的名称不能重复
+func validatePropagateRefs(refs []string, fldPath *field.Path) field.ErrorList {
+	var errs field.ErrorList
+	seen := map[string]bool{}
+	for i, ref := range refs {
+		_, name, ok := SplitObjectRef(ref)
+		if !ok {
+			errs = append(errs, field.Invalid(fldPath.Index(i), ref, "must be <namespace>/<name>"))
+			continue
+		}
+		if seen[name] {
+			errs = append(errs, field.Duplicate(fldPath.Index(i), ref))
+		}
+		seen[name] = true
+	}
+	return errs
+}
+
+// serviceAccountRoles：baseline ServiceAccount 可以绑定的 ClusterRole：owner/admin ClusterRole 与 roleCatalog
+// （和 config/rbac/binder.yaml 里 bind 的 resourceNames 对应）
+func serviceAccountRoles(b *TenantBaselineSpec) []string {
+	owner, admin := DefaultOwnerClusterRole, DefaultAdminClusterRole
+	var catalog []string
+	if r := b.RBAC; r != nil {
+		if v := strings.TrimSpace(r.OwnerClusterRole); v != "" {
+			owner = v
+		}
+		if v := strings.TrimSpace(r.AdminClusterRole); v != "" {
+			admin = v
+		}
+		catalog = r.RoleCatalog
+	}
+	return append([]string{owner, admin}, catalog...)
+}
+
+func validateServiceAccounts(sas []BaselineServiceAccount, roles []string, fldPath *field.Path) field.ErrorList {
+	var errs field.ErrorList
+	seen := map[string]bool{}
+	for i, sa := range sas {
+		p := fldPath.Index(i).Child("name")
+		for _, msg := range validation.IsDNS1123Subdomain(sa.Name) {
+			errs = append(errs, field.Invalid(p, sa.Name, msg))
+		}
+		if sa.Name == "default" {
+			errs = append(errs, field.Forbidden(p, "the default ServiceAccount is created by Kubernetes and cannot be managed"))
+		}
+		if seen[sa.Name] {
+			errs = append(errs, field.Duplicate(p, sa.Name))
+		}
+		seen[sa.Name] = true
+		if role := strings.TrimSpace(sa.ClusterRole); role != "" && !slices.Contains(roles, role) {
+			errs = append(errs, field.NotSupported(fldPath.Index(i).Child("clusterRole"), role, roles))
+		}
+	}
+	return errs
+}
+
+func validateTTL(ttl *TenantTTLSpec, fldPath *field.Path) field.ErrorList {
+	var errs field.ErrorList
+	for _, env := range sortedKeys(ttl.MaxByEnv) {
+		errs = append(errs, validateEnvKey(env, fldPath.Child("maxByEnv").Key(env))...)
+		if ttl.MaxByEnv[env].Duration <= 0 {
+			errs = append(errs, field.Invalid(fldPath.Child("maxByEnv").Key(env), ttl.MaxByEnv[env].Duration.String(), "must be positive"))
+		}
+	}
+	for _, env := range sortedKeys(ttl.DefaultByEnv) {
+		p := fldPath.Child("defaultByEnv").Key(env)
+		errs = append(errs, validateEnvKey(env, p)...)
+		d := ttl.DefaultByEnv[env]
+		if d.Duration <= 0 {
+			errs = append(errs, field.Invalid(p, d.Duration.String(), "must be positive"))
+		} else if m, ok := ttl.MaxByEnv[env]; ok && d.Duration > m.Duration {
+			errs = append(errs, field.Invalid(p, d.Duration.String(), fmt.Sprintf("must not exceed maxByEnv[%s]=%s", env, m.Duration)))
+		}
+	}
+	if ttl.WarnBefore != nil && ttl.WarnBefore.Duration <= 0 {
+		errs = append(errs, field.Invalid(fldPath.Child("warnBefore"), ttl.WarnBefore.Duration.String(), "must be positive"))
+	}
+	return errs
+}
+
+func validateEnvKey(env string, fldPath *field.Path) field.ErrorList {
+	for _, e := range ValidEnvs {
+		if env == e {
+			return nil
+		}
+	}
+	return field.ErrorList{field.NotSupported(fldPath, env, ValidEnvs)}
+}
+
+// namedQuantity keeps field order stable so error messages do not flap between reconciles.
+// +kubebuilder:object:generate=false
+type namedQuantity struct {
+	name  string
+	value string
+}
+
+func validateQuantities(fldPath *field.Path, values []namedQuantity) field.ErrorList {
+	var errs field.ErrorList
+	for _, q := range values {
+		name, v := q.name, strings.TrimSpace(q.value)
+		if v == "" {
+			continue
+		}
+		if _, err := resource.ParseQuantity(v); err != nil {
+			errs = append(errs, field.Invalid(fldPath.Child(name), v, err.Error()))
+		}
+	}
+	return errs
+}
+
+func validateQuotaHard(q QuotaHard, fldPath *field.Path) field.ErrorList {
+	return validateQuantities(fldPath, []namedQuantity{
+		{"requestsCPU", q.RequestsCPU},
+		{"requestsMemory", q.RequestsMemory},
+		{"limitsCPU", q.LimitsCPU},
+		{"limitsMemory", q.LimitsMemory},
+		{"pods", q.Pods},
+		{"services", q.Services},
+		{"configMaps", q.ConfigMaps},
+		{"secrets", q.Secrets},
+		{"persistentVolumeClaims", q.PersistentVolumeClaims},
+		{"nvidiaGPU", q.NvidiaGPU},
+	})
+}
+
+func validateLimitRangeHard(lr LimitRangeHard, fldPath *field.Path) field.ErrorList {
+	return validateQuantities(fldPath, []namedQuantity{
+		{"defaultRequestCPU", lr.DefaultRequestCPU},
+		{"defaultRequestMemory", lr.DefaultRequestMemory},
+		{"defaultLimitCPU", lr.DefaultLimitCPU},
+		{"defaultLimitMemory", lr.DefaultLimitMemory},
+		{"maxCPU", lr.MaxCPU},
+		{"maxMemory", lr.MaxMemory},
+		{"minCPU", lr.MinCPU},
+		{"minMemory", lr.MinMemory},
+	})
+}
+
+func validateCIDRs(cidrs []string, fldPath *field.Path) field.ErrorList {
+	var errs field.ErrorList
+	for i, cidr := range cidrs {
+		if _, _, err := net.ParseCIDR(strings.TrimSpace(cidr)); err != nil {
+			errs = append(errs, field.Invalid(fldPath.Index(i), cidr, err.Error()))
+		}
+	}
+	return errs
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,46 @@
+package v1alpha1
+
+import (
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// DefaultTTLWarnBefore is used when the Tenant does not set ttl.warnBefore.
+const DefaultTTLWarnBefore = 24 * time.Hour
+
+// MaxTTL returns the per-env cap on the remaining lifetime; 0 means no cap.
+func MaxTTL(spec *TenantSpec, env string) time.Duration {
+	if spec.TTL == nil {
+		return 0
+	}
+	return spec.TTL.MaxByEnv[env].Duration
+}
+
+// DefaultTTL returns the per-env default applied when spec.ttl is empty, or nil.
+func DefaultTTL(spec *TenantSpec, env string) *metav1.Duration {
+	if spec.TTL == nil {
+		return nil
+	}
+	if d, ok := spec.TTL.DefaultByEnv[env]; ok {
+		return &d
+	}
+	return nil
+}
+
+// TTLWarnBefore returns how long before expiry the owner is warned.
+func TTLWarnBefore(spec *TenantSpec) time.Duration {
+	if spec.TTL != nil && spec.TTL.WarnBefore != nil && spec.TTL.WarnBefore.Duration > 0 {
+		return spec.TTL.WarnBefore.Duration
+	}
+	return DefaultTTLWarnBefore
+}
+
+// RequestExpiresAt returns creationTimestamp + spec.ttl, or nil when the request has no TTL.
+func RequestExpiresAt(nr *NamespaceRequest) *metav1.Time {
+	if nr.Spec.TTL == nil || nr.CreationTimestamp.IsZero() {
+		return nil
+	}
+	t := metav1.NewTime(nr.CreationTimestamp.Add(nr.Spec.TTL.Duration))
+	return &t
+}
@@ -21,16 +21,152 @@ limitations under the License.
 package v1alpha1
 
 import (
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AdoptionStatus) DeepCopyInto(out *AdoptionStatus) {
+	*out = *in
+	if in.Plan != nil {
+		in, out := &in.Plan, &out.Plan
+		*out = make([]BaselineObjectChange, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AdoptionStatus.
+func (in *AdoptionStatus) DeepCopy() *AdoptionStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(AdoptionStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BaselineManifest) DeepCopyInto(out *BaselineManifest) {
+	*out = *in
+	if in.Envs != nil {
+		in, out := &in.Envs, &out.Envs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BaselineManifest.
+func (in *BaselineManifest) DeepCopy() *BaselineManifest {
+	if in == nil {
+		return nil
+	}
+	out := new(BaselineManifest)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BaselineObjectChange) DeepCopyInto(out *BaselineObjectChange) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BaselineObjectChange.
+func (in *BaselineObjectChange) DeepCopy() *BaselineObjectChange {
+	if in == nil {
+		return nil
+	}
+	out := new(BaselineObjectChange)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BaselineServiceAccount) DeepCopyInto(out *BaselineServiceAccount) {
+	*out = *in
+	if in.ImagePullSecrets != nil {
+		in, out := &in.ImagePullSecrets, &out.ImagePullSecrets
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BaselineServiceAccount.
+func (in *BaselineServiceAccount) DeepCopy() *BaselineServiceAccount {
+	if in == nil {
+		return nil
+	}
+	out := new(BaselineServiceAccount)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BaselineVersionCount) DeepCopyInto(out *BaselineVersionCount) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BaselineVersionCount.
+func (in *BaselineVersionCount) DeepCopy() *BaselineVersionCount {
+	if in == nil {
+		return nil
+	}
+	out := new(BaselineVersionCount)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExtraBinding) DeepCopyInto(out *ExtraBinding) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExtraBinding.
+func (in *ExtraBinding) DeepCopy() *ExtraBinding {
+	if in == nil {
+		return nil
+	}
+	out := new(ExtraBinding)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LimitRangeHard) DeepCopyInto(out *LimitRangeHard) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LimitRangeHard.
+func (in *LimitRangeHard) DeepCopy() *LimitRangeHard {
+	if in == nil {
+		return nil
+	}
+	out := new(LimitRangeHard)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ManifestStatus) DeepCopyInto(out *ManifestStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManifestStatus.
+func (in *ManifestStatus) DeepCopy() *ManifestStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ManifestStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NamespaceRequest) DeepCopyInto(out *NamespaceRequest) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Spec = in.Spec
-	out.Status = in.Status
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NamespaceRequest.
@@ -86,6 +222,16 @@ func (in *NamespaceRequestList) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NamespaceRequestSpec) DeepCopyInto(out *NamespaceRequestSpec) {
 	*out = *in
+	if in.ExtraBindings != nil {
+		in, out := &in.ExtraBindings, &out.ExtraBindings
+		*out = make([]ExtraBinding, len(*in))
+		copy(*out, *in)
+	}
+	if in.TTL != nil {
+		in, out := &in.TTL, &out.TTL
+		*out = new(v1.Duration)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NamespaceRequestSpec.
@@ -101,6 +247,44 @@ func (in *NamespaceRequestSpec) DeepCopy() *NamespaceRequestSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NamespaceRequestStatus) DeepCopyInto(out *NamespaceRequestStatus) {
 	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.OwnerHistory != nil {
+		in, out := &in.OwnerHistory, &out.OwnerHistory
+		*out = make([]OwnerTransfer, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Adoption != nil {
+		in, out := &in.Adoption, &out.Adoption
+		*out = new(AdoptionStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ServiceAccounts != nil {
+		in, out := &in.ServiceAccounts, &out.ServiceAccounts
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Manifests != nil {
+		in, out := &in.Manifests, &out.Manifests
+		*out = make([]ManifestStatus, len(*in))
+		copy(*out, *in)
+	}
+	if in.Retry != nil {
+		in, out := &in.Retry, &out.Retry
+		*out = new(RetryStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ExpiresAt != nil {
+		in, out := &in.ExpiresAt, &out.ExpiresAt
+		*out = (*in).DeepCopy()
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NamespaceRequestStatus.
@@ -113,6 +297,22 @@ func (in *NamespaceRequestStatus) DeepCopy() *NamespaceRequestStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OwnerTransfer) DeepCopyInto(out *OwnerTransfer) {
+	*out = *in
+	in.Time.DeepCopyInto(&out.Time)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OwnerTransfer.
+func (in *OwnerTransfer) DeepCopy() *OwnerTransfer {
+	if in == nil {
+		return nil
+	}
+	out := new(OwnerTransfer)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *QuotaHard) DeepCopyInto(out *QuotaHard) {
 	*out = *in
@@ -128,13 +328,68 @@ func (in *QuotaHard) DeepCopy() *QuotaHard {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReclaimPolicy) DeepCopyInto(out *ReclaimPolicy) {
+	*out = *in
+	if in.RetainGracePeriod != nil {
+		in, out := &in.RetainGracePeriod, &out.RetainGracePeriod
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReclaimPolicy.
+func (in *ReclaimPolicy) DeepCopy() *ReclaimPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(ReclaimPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RetryStatus) DeepCopyInto(out *RetryStatus) {
+	*out = *in
+	if in.NextRetryAt != nil {
+		in, out := &in.NextRetryAt, &out.NextRetryAt
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RetryStatus.
+func (in *RetryStatus) DeepCopy() *RetryStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RetryStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RolloutAdmission) DeepCopyInto(out *RolloutAdmission) {
+	*out = *in
+	in.AdmittedAt.DeepCopyInto(&out.AdmittedAt)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RolloutAdmission.
+func (in *RolloutAdmission) DeepCopy() *RolloutAdmission {
+	if in == nil {
+		return nil
+	}
+	out := new(RolloutAdmission)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Tenant) DeepCopyInto(out *Tenant) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Tenant.
@@ -155,6 +410,133 @@ func (in *Tenant) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TenantApprovalSpec) DeepCopyInto(out *TenantApprovalSpec) {
+	*out = *in
+	if in.Envs != nil {
+		in, out := &in.Envs, &out.Envs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ApproverGroups != nil {
+		in, out := &in.ApproverGroups, &out.ApproverGroups
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TenantApprovalSpec.
+func (in *TenantApprovalSpec) DeepCopy() *TenantApprovalSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(TenantApprovalSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TenantBaselineSpec) DeepCopyInto(out *TenantBaselineSpec) {
+	*out = *in
+	if in.RBAC != nil {
+		in, out := &in.RBAC, &out.RBAC
+		*out = new(TenantRBACSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Quota != nil {
+		in, out := &in.Quota, &out.Quota
+		*out = new(TenantQuotaSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.LimitRange != nil {
+		in, out := &in.LimitRange, &out.LimitRange
+		*out = new(TenantLimitRangeSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NetworkPolicy != nil {
+		in, out := &in.NetworkPolicy, &out.NetworkPolicy
+		*out = new(TenantNetworkPolicySpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ServiceAccounts != nil {
+		in, out := &in.ServiceAccounts, &out.ServiceAccounts
+		*out = new(TenantServiceAccountSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Propagate != nil {
+		in, out := &in.Propagate, &out.Propagate
+		*out = new(TenantPropagateSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Manifests != nil {
+		in, out := &in.Manifests, &out.Manifests
+		*out = make([]BaselineManifest, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Conflicts != nil {
+		in, out := &in.Conflicts, &out.Conflicts
+		*out = new(TenantConflictSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TenantBaselineSpec.
+func (in *TenantBaselineSpec) DeepCopy() *TenantBaselineSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(TenantBaselineSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TenantConflictSpec) DeepCopyInto(out *TenantConflictSpec) {
+	*out = *in
+	if in.ByComponent != nil {
+		in, out := &in.ByComponent, &out.ByComponent
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TenantConflictSpec.
+func (in *TenantConflictSpec) DeepCopy() *TenantConflictSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(TenantConflictSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TenantLimitRangeSpec) DeepCopyInto(out *TenantLimitRangeSpec) {
+	*out = *in
+	out.Default = in.Default
+	if in.ByEnv != nil {
+		in, out := &in.ByEnv, &out.ByEnv
+		*out = make(map[string]LimitRangeHard, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TenantLimitRangeSpec.
+func (in *TenantLimitRangeSpec) DeepCopy() *TenantLimitRangeSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(TenantLimitRangeSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TenantList) DeepCopyInto(out *TenantList) {
 	*out = *in
@@ -187,6 +569,93 @@ func (in *TenantList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TenantNamingSpec) DeepCopyInto(out *TenantNamingSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TenantNamingSpec.
+func (in *TenantNamingSpec) DeepCopy() *TenantNamingSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(TenantNamingSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TenantNetworkPolicyEnvOverride) DeepCopyInto(out *TenantNetworkPolicyEnvOverride) {
+	*out = *in
+	if in.AllowEgressCIDRs != nil {
+		in, out := &in.AllowEgressCIDRs, &out.AllowEgressCIDRs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TenantNetworkPolicyEnvOverride.
+func (in *TenantNetworkPolicyEnvOverride) DeepCopy() *TenantNetworkPolicyEnvOverride {
+	if in == nil {
+		return nil
+	}
+	out := new(TenantNetworkPolicyEnvOverride)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TenantNetworkPolicySpec) DeepCopyInto(out *TenantNetworkPolicySpec) {
+	*out = *in
+	if in.AllowEgressCIDRs != nil {
+		in, out := &in.AllowEgressCIDRs, &out.AllowEgressCIDRs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ByEnv != nil {
+		in, out := &in.ByEnv, &out.ByEnv
+		*out = make(map[string]TenantNetworkPolicyEnvOverride, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TenantNetworkPolicySpec.
+func (in *TenantNetworkPolicySpec) DeepCopy() *TenantNetworkPolicySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(TenantNetworkPolicySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TenantPropagateSpec) DeepCopyInto(out *TenantPropagateSpec) {
+	*out = *in
+	if in.Secrets != nil {
+		in, out := &in.Secrets, &out.Secrets
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ConfigMaps != nil {
+		in, out := &in.ConfigMaps, &out.ConfigMaps
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TenantPropagateSpec.
+func (in *TenantPropagateSpec) DeepCopy() *TenantPropagateSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(TenantPropagateSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TenantQuotaSpec) DeepCopyInto(out *TenantQuotaSpec) {
 	*out = *in
@@ -198,6 +667,24 @@ func (in *TenantQuotaSpec) DeepCopyInto(out *TenantQuotaSpec) {
 			(*out)[key] = val
 		}
 	}
+	if in.Sizes != nil {
+		in, out := &in.Sizes, &out.Sizes
+		*out = make(map[string]map[string]QuotaHard, len(*in))
+		for key, val := range *in {
+			var outVal map[string]QuotaHard
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				inVal := (*in)[key]
+				in, out := &inVal, &outVal
+				*out = make(map[string]QuotaHard, len(*in))
+				for key, val := range *in {
+					(*out)[key] = val
+				}
+			}
+			(*out)[key] = outVal
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TenantQuotaSpec.
@@ -210,6 +697,140 @@ func (in *TenantQuotaSpec) DeepCopy() *TenantQuotaSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TenantRBACSpec) DeepCopyInto(out *TenantRBACSpec) {
+	*out = *in
+	if in.RoleCatalog != nil {
+		in, out := &in.RoleCatalog, &out.RoleCatalog
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TenantRBACSpec.
+func (in *TenantRBACSpec) DeepCopy() *TenantRBACSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(TenantRBACSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TenantReclaimSpec) DeepCopyInto(out *TenantReclaimSpec) {
+	*out = *in
+	in.Default.DeepCopyInto(&out.Default)
+	if in.ByEnv != nil {
+		in, out := &in.ByEnv, &out.ByEnv
+		*out = make(map[string]ReclaimPolicy, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TenantReclaimSpec.
+func (in *TenantReclaimSpec) DeepCopy() *TenantReclaimSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(TenantReclaimSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TenantRolloutSpec) DeepCopyInto(out *TenantRolloutSpec) {
+	*out = *in
+	if in.Stages != nil {
+		in, out := &in.Stages, &out.Stages
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.SoakPeriod != nil {
+		in, out := &in.SoakPeriod, &out.SoakPeriod
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TenantRolloutSpec.
+func (in *TenantRolloutSpec) DeepCopy() *TenantRolloutSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(TenantRolloutSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TenantRolloutStatus) DeepCopyInto(out *TenantRolloutStatus) {
+	*out = *in
+	if in.SoakUntil != nil {
+		in, out := &in.SoakUntil, &out.SoakUntil
+		*out = (*in).DeepCopy()
+	}
+	if in.Admitted != nil {
+		in, out := &in.Admitted, &out.Admitted
+		*out = make([]RolloutAdmission, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TenantRolloutStatus.
+func (in *TenantRolloutStatus) DeepCopy() *TenantRolloutStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(TenantRolloutStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TenantServiceAccountSpec) DeepCopyInto(out *TenantServiceAccountSpec) {
+	*out = *in
+	if in.Default != nil {
+		in, out := &in.Default, &out.Default
+		*out = make([]BaselineServiceAccount, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ByEnv != nil {
+		in, out := &in.ByEnv, &out.ByEnv
+		*out = make(map[string][]BaselineServiceAccount, len(*in))
+		for key, val := range *in {
+			var outVal []BaselineServiceAccount
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				inVal := (*in)[key]
+				in, out := &inVal, &outVal
+				*out = make([]BaselineServiceAccount, len(*in))
+				for i := range *in {
+					(*in)[i].DeepCopyInto(&(*out)[i])
+				}
+			}
+			(*out)[key] = outVal
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TenantServiceAccountSpec.
+func (in *TenantServiceAccountSpec) DeepCopy() *TenantServiceAccountSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(TenantServiceAccountSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TenantSpec) DeepCopyInto(out *TenantSpec) {
 	*out = *in
@@ -218,9 +839,34 @@ func (in *TenantSpec) DeepCopyInto(out *TenantSpec) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
-	if in.Quota != nil {
-		in, out := &in.Quota, &out.Quota
-		*out = new(TenantQuotaSpec)
+	if in.Naming != nil {
+		in, out := &in.Naming, &out.Naming
+		*out = new(TenantNamingSpec)
+		**out = **in
+	}
+	if in.Baseline != nil {
+		in, out := &in.Baseline, &out.Baseline
+		*out = new(TenantBaselineSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Rollout != nil {
+		in, out := &in.Rollout, &out.Rollout
+		*out = new(TenantRolloutSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Approval != nil {
+		in, out := &in.Approval, &out.Approval
+		*out = new(TenantApprovalSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TTL != nil {
+		in, out := &in.TTL, &out.TTL
+		*out = new(TenantTTLSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Reclaim != nil {
+		in, out := &in.Reclaim, &out.Reclaim
+		*out = new(TenantReclaimSpec)
 		(*in).DeepCopyInto(*out)
 	}
 }
@@ -238,6 +884,23 @@ func (in *TenantSpec) DeepCopy() *TenantSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TenantStatus) DeepCopyInto(out *TenantStatus) {
 	*out = *in
+	if in.BaselineVersions != nil {
+		in, out := &in.BaselineVersions, &out.BaselineVersions
+		*out = make([]BaselineVersionCount, len(*in))
+		copy(*out, *in)
+	}
+	if in.Rollout != nil {
+		in, out := &in.Rollout, &out.Rollout
+		*out = new(TenantRolloutStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TenantStatus.
@@ -249,3 +912,37 @@ func (in *TenantStatus) DeepCopy() *TenantStatus {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TenantTTLSpec) DeepCopyInto(out *TenantTTLSpec) {
+	*out = *in
+	if in.MaxByEnv != nil {
+		in, out := &in.MaxByEnv, &out.MaxByEnv
+		*out = make(map[string]v1.Duration, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.DefaultByEnv != nil {
+		in, out := &in.DefaultByEnv, &out.DefaultByEnv
+		*out = make(map[string]v1.Duration, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.WarnBefore != nil {
+		in, out := &in.WarnBefore, &out.WarnBefore
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TenantTTLSpec.
+func (in *TenantTTLSpec) DeepCopy() *TenantTTLSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(TenantTTLSpec)
+	in.DeepCopyInto(out)
+	return out
+}
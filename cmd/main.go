@@ -25,10 +25,14 @@ import (
 	// to ensure that exec-entrypoint and run can make use of them.
 	_ "k8s.io/client-go/plugin/pkg/client/auth"
 
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 	"sigs.k8s.io/controller-runtime/pkg/metrics/filters"
@@ -155,8 +159,24 @@ func main() {
 		metricsServerOptions.KeyName = metricsCertKey
 	}
 
+	// Secret/ConfigMap：informer 只缓存带 guardian.io/propagation 标签的（复制来源与复制品），
+	// 其余读写直接访问 apiserver，不在内存里缓存全集群的 Secret
+	propagationSelector, err := labels.Parse(guardianv1alpha1.LabelPropagation + " in (" +
+		guardianv1alpha1.PropagationSource + "," + guardianv1alpha1.PropagationCopy + ")")
+	if err != nil {
+		setupLog.Error(err, "unable to build the propagation label selector")
+		os.Exit(1)
+	}
+
 	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), ctrl.Options{
-		Scheme:                 scheme,
+		Scheme: scheme,
+		Cache: cache.Options{ByObject: map[client.Object]cache.ByObject{
+			&corev1.Secret{}:    {Label: propagationSelector},
+			&corev1.ConfigMap{}: {Label: propagationSelector},
+		}},
+		Client: client.Options{Cache: &client.CacheOptions{
+			DisableFor: []client.Object{&corev1.Secret{}, &corev1.ConfigMap{}},
+		}},
 		Metrics:                metricsServerOptions,
 		WebhookServer:          webhookServer,
 		HealthProbeBindAddress: probeAddr,
@@ -187,8 +207,9 @@ func main() {
 		os.Exit(1)
 	}
 	if err := (&controller.NamespaceRequestReconciler{
-		Client: mgr.GetClient(),
-		Scheme: mgr.GetScheme(),
+		Client:   mgr.GetClient(),
+		Scheme:   mgr.GetScheme(),
+		Recorder: mgr.GetEventRecorderFor("namespacerequest-controller"),
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "NamespaceRequest")
 		os.Exit(1)
@@ -199,6 +220,10 @@ func main() {
 			setupLog.Error(err, "unable to create webhook", "webhook", "NamespaceRequest")
 			os.Exit(1)
 		}
+		if err := webhookv1alpha1.SetupTenantWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "Tenant")
+			os.Exit(1)
+		}
 	}
 	// +kubebuilder:scaffold:builder
 
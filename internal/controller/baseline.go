@@ -2,20 +2,24 @@ package controller
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	guardiov1alpha1 "github.com/CATDOGME/namespace-guardian/api/v1alpha1"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"net"
 	"strings"
 
 	corev1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
 
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 
 	"sigs.k8s.io/controller-runtime/pkg/client"
-	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
 )
 
 // BaselineSpec：后续你可以把这些默认值挪到 Tenant CRD / ConfigMap / flags
@@ -24,24 +28,179 @@ type BaselineSpec struct {
 	Tenant     string
 	Env        string
 	OwnerGroup string
-	TenantObj  *guardiov1alpha1.Tenant
+	Size       string // NamespaceRequest.spec.size，空表示不叠加规格
+	// ExtraBindings：NamespaceRequest.spec.extraBindings（已由 webhook 按 role catalog / allowedGroups 校验）
+	ExtraBindings []guardiov1alpha1.ExtraBinding
+	TenantObj     *guardiov1alpha1.Tenant
+	// Version：Tenant 要求的 baseline 版本（空表示 v1）；namespace 已在更新的版本时按 namespace 上的版本渲染
+	Version string
 
 	// 用于追踪/审计
 	RequestName string
+	// Requester：NamespaceRequest 上的申请人身份注解，原样写到每个 baseline 对象上
+	Requester map[string]string
+	// PreviousManifests：上次下发的 manifests（NamespaceRequest status），用于清理不再需要的对象
+	PreviousManifests []guardiov1alpha1.ManifestStatus
+
+	// changes 记录本次实际创建/更新的对象（Kind/name），由 EnsureBaseline 填充
+	changes *[]string
+	// plan 非空时为 dry-run：记录将要创建/覆盖的对象，由 PlanBaseline 填充
+	plan *[]guardiov1alpha1.BaselineObjectChange
+	// manifests 非空时写入 Tenant baseline.manifests 的逐个下发结果
+	manifests *[]guardiov1alpha1.ManifestStatus
+	// conflicts 非空时记录各组件（condition 类型）被强制接管的 server-side apply 冲突
+	conflicts map[string][]string
+	// component：当前下发的组件（condition 类型），决定冲突策略；由 EnsureBaseline 逐步设置
+	component string
+	// desired 记录本次下发的对象（Kind/name），EnsureBaseline 最后据此清理不再需要的对象
+	desired map[string]bool
+	// serviceAccounts 非空时记录实际下发的 ServiceAccount 名称（写到 NamespaceRequest status）
+	serviceAccounts *[]string
+	// takeOver：接管 namespace 时确认过的 plan 里要覆盖/替换的已有对象（Kind/name），只有它们可以覆盖不属于本 request 的对象
+	takeOver map[string]bool
+	// recorded：request 之前下发过的对象（Kind/name，来自 status.serviceAccounts / status.manifests）；
+	// 非 nil 表示 request 已在这个 namespace 下发过，guardian 固定命名的对象也算在内
+	recorded map[string]bool
 }
 
-func selectQuotaHard(t *guardiov1alpha1.Tenant, env string) (guardiov1alpha1.QuotaHard, bool) {
-	if t == nil || t.Spec.Quota == nil {
-		return guardiov1alpha1.QuotaHard{}, false
+// selectQuotaHard：default -> byEnv[env] -> sizes[env][size] 按字段覆盖（覆盖层只需写要改的字段）
+// 指定了 size 但 Tenant 不再提供时返回错误，而不是悄悄退回默认配额
+func selectQuotaHard(t *guardiov1alpha1.Tenant, env, size string) (guardiov1alpha1.QuotaHard, bool, error) {
+	if t == nil || t.Spec.Baseline == nil || t.Spec.Baseline.Quota == nil {
+		if size != "" {
+			return guardiov1alpha1.QuotaHard{}, false, permanent(fmt.Errorf("tenant offers no quota sizes, requested size %q", size))
+		}
+		return guardiov1alpha1.QuotaHard{}, false, nil
 	}
+	quota := t.Spec.Baseline.Quota
+	out := quota.Default
 	// env 覆盖优先
-	if t.Spec.Quota.ByEnv != nil {
-		if q, ok := t.Spec.Quota.ByEnv[env]; ok {
-			return q, true
+	if q, ok := quota.ByEnv[env]; ok {
+		out = mergeQuotaHard(out, q)
+	}
+	if size != "" {
+		q, ok := guardiov1alpha1.QuotaSize(&t.Spec, env, size)
+		if !ok {
+			return out, false, permanent(fmt.Errorf("quota size %q is not offered for env %q (available: %v)",
+				size, env, guardiov1alpha1.QuotaSizeNames(&t.Spec, env)))
+		}
+		out = mergeQuotaHard(out, q)
+	}
+	return out, true, nil
+}
+
+func mergeQuotaHard(base, override guardiov1alpha1.QuotaHard) guardiov1alpha1.QuotaHard {
+	pick := func(b, o string) string {
+		if strings.TrimSpace(o) != "" {
+			return o
+		}
+		return b
+	}
+	return guardiov1alpha1.QuotaHard{
+		RequestsCPU:            pick(base.RequestsCPU, override.RequestsCPU),
+		RequestsMemory:         pick(base.RequestsMemory, override.RequestsMemory),
+		LimitsCPU:              pick(base.LimitsCPU, override.LimitsCPU),
+		LimitsMemory:           pick(base.LimitsMemory, override.LimitsMemory),
+		Pods:                   pick(base.Pods, override.Pods),
+		Services:               pick(base.Services, override.Services),
+		ConfigMaps:             pick(base.ConfigMaps, override.ConfigMaps),
+		Secrets:                pick(base.Secrets, override.Secrets),
+		PersistentVolumeClaims: pick(base.PersistentVolumeClaims, override.PersistentVolumeClaims),
+		NvidiaGPU:              pick(base.NvidiaGPU, override.NvidiaGPU),
+	}
+}
+
+// selectLimitRange：全局默认 -> default -> byEnv[env] 按字段覆盖
+func selectLimitRange(t *guardiov1alpha1.Tenant, env string) guardiov1alpha1.LimitRangeHard {
+	out := defaultGlobalLimitRange()
+	if t == nil || t.Spec.Baseline == nil || t.Spec.Baseline.LimitRange == nil {
+		return out
+	}
+	lr := t.Spec.Baseline.LimitRange
+	out = mergeLimitRangeHard(out, lr.Default)
+	if o, ok := lr.ByEnv[env]; ok {
+		out = mergeLimitRangeHard(out, o)
+	}
+	return out
+}
+
+func mergeLimitRangeHard(base, override guardiov1alpha1.LimitRangeHard) guardiov1alpha1.LimitRangeHard {
+	pick := func(b, o string) string {
+		if strings.TrimSpace(o) != "" {
+			return o
+		}
+		return b
+	}
+	return guardiov1alpha1.LimitRangeHard{
+		DefaultRequestCPU:    pick(base.DefaultRequestCPU, override.DefaultRequestCPU),
+		DefaultRequestMemory: pick(base.DefaultRequestMemory, override.DefaultRequestMemory),
+		DefaultLimitCPU:      pick(base.DefaultLimitCPU, override.DefaultLimitCPU),
+		DefaultLimitMemory:   pick(base.DefaultLimitMemory, override.DefaultLimitMemory),
+		MaxCPU:               pick(base.MaxCPU, override.MaxCPU),
+		MaxMemory:            pick(base.MaxMemory, override.MaxMemory),
+		MinCPU:               pick(base.MinCPU, override.MinCPU),
+		MinMemory:            pick(base.MinMemory, override.MinMemory),
+	}
+}
+
+// selectNetworkPolicy：返回最终生效的 profile 与 egress CIDR
+// byEnv 的 profile 非空时覆盖；byEnv 的 allowEgressCIDRs 非 nil 时整体替换
+func selectNetworkPolicy(t *guardiov1alpha1.Tenant, env string) (string, []string) {
+	if t == nil || t.Spec.Baseline == nil || t.Spec.Baseline.NetworkPolicy == nil {
+		return guardiov1alpha1.NPProfileStandard, nil
+	}
+	np := t.Spec.Baseline.NetworkPolicy
+	profile := np.Profile
+	cidrs := np.AllowEgressCIDRs
+	if o, ok := np.ByEnv[env]; ok {
+		if o.Profile != "" {
+			profile = o.Profile
+		}
+		if o.AllowEgressCIDRs != nil {
+			cidrs = o.AllowEgressCIDRs
+		}
+	}
+	if profile == "" {
+		profile = guardiov1alpha1.NPProfileStandard
+	}
+	return profile, cidrs
+}
+
+// selectServiceAccounts：default 加上 byEnv[env]，同名的 env 条目替换 default
+func selectServiceAccounts(t *guardiov1alpha1.Tenant, env string) []guardiov1alpha1.BaselineServiceAccount {
+	if t == nil || t.Spec.Baseline == nil || t.Spec.Baseline.ServiceAccounts == nil {
+		return nil
+	}
+	sa := t.Spec.Baseline.ServiceAccounts
+	out := make([]guardiov1alpha1.BaselineServiceAccount, 0, len(sa.Default)+len(sa.ByEnv[env]))
+	idx := map[string]int{}
+	for _, list := range [][]guardiov1alpha1.BaselineServiceAccount{sa.Default, sa.ByEnv[env]} {
+		for _, a := range list {
+			if i, ok := idx[a.Name]; ok {
+				out[i] = a
+				continue
+			}
+			idx[a.Name] = len(out)
+			out = append(out, a)
 		}
 	}
-	// fallback default
-	return t.Spec.Quota.Default, true
+	return out
+}
+
+// selectClusterRoles：owner/admin 绑定的 ClusterRole，未配置时使用默认值
+func selectClusterRoles(t *guardiov1alpha1.Tenant) (owner, admin string) {
+	owner = guardiov1alpha1.DefaultOwnerClusterRole
+	admin = guardiov1alpha1.DefaultAdminClusterRole
+	if t == nil || t.Spec.Baseline == nil || t.Spec.Baseline.RBAC == nil {
+		return owner, admin
+	}
+	if r := strings.TrimSpace(t.Spec.Baseline.RBAC.OwnerClusterRole); r != "" {
+		owner = r
+	}
+	if r := strings.TrimSpace(t.Spec.Baseline.RBAC.AdminClusterRole); r != "" {
+		admin = r
+	}
+	return owner, admin
 }
 
 func quotaHardToResourceList(q guardiov1alpha1.QuotaHard) (corev1.ResourceList, error) {
@@ -54,7 +213,7 @@ func quotaHardToResourceList(q guardiov1alpha1.QuotaHard) (corev1.ResourceList,
 		}
 		qty, err := resource.ParseQuantity(s)
 		if err != nil {
-			return fmt.Errorf("invalid quantity for %s=%q: %w", name, s, err)
+			return permanent(fmt.Errorf("invalid quantity for %s=%q: %w", name, s, err))
 		}
 		out[name] = qty
 		return nil
@@ -99,34 +258,157 @@ func quotaHardToResourceList(q guardiov1alpha1.QuotaHard) (corev1.ResourceList,
 	return out, nil
 }
 
-// EnsureBaseline 在 namespace 内创建/更新：RBAC + Quota + LimitRange + NetworkPolicy
-func EnsureBaseline(ctx context.Context, c client.Client, namespace string, spec BaselineSpec) error {
+func limitRangeHardToItem(lr guardiov1alpha1.LimitRangeHard) (corev1.LimitRangeItem, error) {
+	item := corev1.LimitRangeItem{Type: corev1.LimitTypeContainer}
+
+	put := func(dst *corev1.ResourceList, field string, name corev1.ResourceName, s string) error {
+		s = strings.TrimSpace(s)
+		if s == "" {
+			return nil
+		}
+		qty, err := resource.ParseQuantity(s)
+		if err != nil {
+			return permanent(fmt.Errorf("invalid quantity for %s=%q: %w", field, s, err))
+		}
+		if *dst == nil {
+			*dst = corev1.ResourceList{}
+		}
+		(*dst)[name] = qty
+		return nil
+	}
+
+	for _, f := range []struct {
+		dst   *corev1.ResourceList
+		field string
+		name  corev1.ResourceName
+		val   string
+	}{
+		{&item.DefaultRequest, "defaultRequestCPU", corev1.ResourceCPU, lr.DefaultRequestCPU},
+		{&item.DefaultRequest, "defaultRequestMemory", corev1.ResourceMemory, lr.DefaultRequestMemory},
+		{&item.Default, "defaultLimitCPU", corev1.ResourceCPU, lr.DefaultLimitCPU},
+		{&item.Default, "defaultLimitMemory", corev1.ResourceMemory, lr.DefaultLimitMemory},
+		{&item.Max, "maxCPU", corev1.ResourceCPU, lr.MaxCPU},
+		{&item.Max, "maxMemory", corev1.ResourceMemory, lr.MaxMemory},
+		{&item.Min, "minCPU", corev1.ResourceCPU, lr.MinCPU},
+		{&item.Min, "minMemory", corev1.ResourceMemory, lr.MinMemory},
+	} {
+		if err := put(f.dst, f.field, f.name, f.val); err != nil {
+			return corev1.LimitRangeItem{}, err
+		}
+	}
+	return item, nil
+}
+
+// BaselineError 标记是哪个 baseline 组件失败，Condition 对应 NamespaceRequest 上的 *Ready condition
+type BaselineError struct {
+	Condition string
+	Err       error
+}
+
+func (e *BaselineError) Error() string { return e.Err.Error() }
+func (e *BaselineError) Unwrap() error { return e.Err }
+
+// errBaselineVersionUnresolved：第 0 步解析 baseline 版本失败，其他组件都还没执行（迁移失败则是最后一步）
+var errBaselineVersionUnresolved = errors.New("resolve baseline version")
+
+// EnsureBaseline 在 namespace 内按 baseline 版本 server-side apply：RBAC + Quota + LimitRange + NetworkPolicy，再做版本迁移
+// 返回实际发生变化的对象（Kind/name），对已 Provisioned 的 namespace 来说就是被修复的漂移
+func EnsureBaseline(ctx context.Context, c client.Client, namespace string, spec BaselineSpec) ([]string, error) {
+	var changed []string
+	spec.changes = &changed
+	spec.desired = map[string]bool{}
+
+	// 0) 版本：按目标版本渲染，完成后再清理旧版本的遗留对象
+	version, applied, err := baselineVersions(ctx, c, namespace, spec)
+	if err != nil {
+		return changed, &BaselineError{guardiov1alpha1.CondBaselineVersionReady, fmt.Errorf("%w: %w", errBaselineVersionUnresolved, err)}
+	}
+	spec.Version = version
+
 	// 1) RBAC：ownerGroup -> edit
+	spec.component = guardiov1alpha1.CondRBACReady
 	if err := ensureOwnerEditRoleBinding(ctx, c, namespace, spec); err != nil {
-		return fmt.Errorf("ensure owner edit rolebinding: %w", err)
+		return changed, &BaselineError{guardiov1alpha1.CondRBACReady, fmt.Errorf("ensure owner edit rolebinding: %w", err)}
 	}
 
 	// 2) RBAC：adminGroup -> admin（可选但生产常用）
 	if err := ensureTenantAdminRoleBinding(ctx, c, namespace, spec); err != nil {
-		return fmt.Errorf("ensure tenant admin rolebinding: %w", err)
+		return changed, &BaselineError{guardiov1alpha1.CondRBACReady, fmt.Errorf("ensure tenant admin rolebinding: %w", err)}
+	}
+
+	// 2.1) RBAC：extraBindings（已从 spec 中移除的由第 8 步清理）
+	if err := ensureExtraRoleBindings(ctx, c, namespace, spec); err != nil {
+		return changed, &BaselineError{guardiov1alpha1.CondRBACReady, fmt.Errorf("ensure extra rolebindings: %w", err)}
+	}
+
+	// 2.2) ServiceAccounts（CI deployer 等）及其 RoleBinding
+	spec.component = guardiov1alpha1.CondServiceAccountsReady
+	if err := ensureServiceAccounts(ctx, c, namespace, spec); err != nil {
+		return changed, &BaselineError{guardiov1alpha1.CondServiceAccountsReady, fmt.Errorf("ensure serviceaccounts: %w", err)}
+	}
+
+	// 2.3) 从来源 namespace 复制的 Secret/ConfigMap（镜像拉取凭证、CA bundle 等）
+	spec.component = guardiov1alpha1.CondPropagationReady
+	if err := ensurePropagatedObjects(ctx, c, namespace, spec); err != nil {
+		return changed, &BaselineError{guardiov1alpha1.CondPropagationReady, fmt.Errorf("ensure propagated objects: %w", err)}
 	}
 
 	// 3) ResourceQuota
+	spec.component = guardiov1alpha1.CondQuotaReady
 	if err := ensureResourceQuota(ctx, c, namespace, spec); err != nil {
-		return fmt.Errorf("ensure resourcequota: %w", err)
+		return changed, &BaselineError{guardiov1alpha1.CondQuotaReady, fmt.Errorf("ensure resourcequota: %w", err)}
 	}
 
 	// 4) LimitRange
+	spec.component = guardiov1alpha1.CondLimitRangeReady
 	if err := ensureLimitRange(ctx, c, namespace, spec); err != nil {
-		return fmt.Errorf("ensure limitrange: %w", err)
+		return changed, &BaselineError{guardiov1alpha1.CondLimitRangeReady, fmt.Errorf("ensure limitrange: %w", err)}
 	}
 
-	// 5) NetworkPolicy：deny + allow-dns + allow-same-namespace
+	// 5) NetworkPolicy：按 profile 下发（standard/strict/open）+ egress CIDR 放行
+	spec.component = guardiov1alpha1.CondNetworkPolicyReady
 	if err := ensureNetworkPolicies(ctx, c, namespace, spec); err != nil {
-		return fmt.Errorf("ensure networkpolicies: %w", err)
+		return changed, &BaselineError{guardiov1alpha1.CondNetworkPolicyReady, fmt.Errorf("ensure networkpolicies: %w", err)}
 	}
 
-	return nil
+	// 6) Tenant 自定义的模板对象（PDB、Role、Sidecar 等）
+	spec.component = guardiov1alpha1.CondManifestsReady
+	if err := ensureManifests(ctx, c, namespace, spec); err != nil {
+		return changed, &BaselineError{guardiov1alpha1.CondManifestsReady, fmt.Errorf("ensure manifests: %w", err)}
+	}
+
+	// 7) 版本迁移：vN -> vN+1 逐步删除旧版本遗留的对象，并在 namespace 上记录版本
+	spec.component = guardiov1alpha1.CondBaselineVersionReady
+	if err := migrateBaseline(ctx, c, namespace, applied, version, spec); err != nil {
+		return changed, &BaselineError{guardiov1alpha1.CondBaselineVersionReady, err}
+	}
+
+	// 8) 清理：本 request 下发过、这次不再需要的对象（profile 改为 open 后的 NetworkPolicy、移除的 ServiceAccount 等）
+	if err := pruneBaseline(ctx, c, namespace, spec); err != nil {
+		return changed, err
+	}
+
+	return changed, nil
+}
+
+// PlanBaseline 用 dry-run client 跑一遍 EnsureBaseline，返回将被创建/覆盖的对象，不做任何实际写入
+// （接管已有 namespace 前给人确认用）
+func PlanBaseline(ctx context.Context, c client.Client, namespace string, spec BaselineSpec) ([]guardiov1alpha1.BaselineObjectChange, error) {
+	var plan []guardiov1alpha1.BaselineObjectChange
+	spec.plan = &plan
+	_, err := EnsureBaseline(ctx, client.NewDryRunClient(c), namespace, spec)
+	return plan, err
+}
+
+func recordPlan(spec BaselineSpec, kind, name, action string) {
+	*spec.plan = append(*spec.plan, guardiov1alpha1.BaselineObjectChange{Kind: kind, Name: name, Action: action})
+}
+
+func objectKind(c client.Client, obj client.Object) string {
+	if gvk, err := apiutil.GVKForObject(obj, c.Scheme()); err == nil {
+		return gvk.Kind
+	}
+	return fmt.Sprintf("%T", obj)
 }
 
 func baselineLabels(spec BaselineSpec) map[string]string {
@@ -141,52 +423,116 @@ func baselineLabels(spec BaselineSpec) map[string]string {
 }
 
 func ensureOwnerEditRoleBinding(ctx context.Context, c client.Client, ns string, spec BaselineSpec) error {
-	name := "guardian-owner-edit"
-	rb := &rbacv1.RoleBinding{
-		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: ns},
+	ownerRole, _ := selectClusterRoles(spec.TenantObj)
+	return ensureGroupRoleBinding(ctx, c, ns, "guardian-owner-edit", spec.OwnerGroup, ownerRole, spec)
+}
+
+func ensureTenantAdminRoleBinding(ctx context.Context, c client.Client, ns string, spec BaselineSpec) error {
+	_, adminRole := selectClusterRoles(spec.TenantObj)
+	adminGroup := spec.Tenant + ":ns-admin"
+	return ensureGroupRoleBinding(ctx, c, ns, tenantAdminRoleBindingName(spec.Version), adminGroup, adminRole, spec)
+}
+
+// ensureGroupRoleBinding：Group -> ClusterRole 的 RoleBinding
+func ensureGroupRoleBinding(ctx context.Context, c client.Client, ns, name, group, clusterRole string, spec BaselineSpec) error {
+	subject := rbacv1.Subject{
+		Kind:     rbacv1.GroupKind,
+		APIGroup: rbacv1.GroupName,
+		Name:     group,
 	}
+	return ensureRoleBinding(ctx, c, ns, name, subject, clusterRole, spec)
+}
 
-	_, err := controllerutil.CreateOrUpdate(ctx, c, rb, func() error {
-		ensureBaselineMeta(&rb.ObjectMeta, spec)
-		rb.Subjects = []rbacv1.Subject{
-			{
-				Kind:     rbacv1.GroupKind,
-				APIGroup: rbacv1.GroupName,
-				Name:     spec.OwnerGroup,
-			},
+// ensureExtraRoleBindings：每个 extraBinding 一个 guardian-extra-<hash> RoleBinding；
+// 从 spec 中移除的由 pruneBaseline 统一清理
+func ensureExtraRoleBindings(ctx context.Context, c client.Client, ns string, spec BaselineSpec) error {
+	for _, b := range spec.ExtraBindings {
+		subject := rbacv1.Subject{Kind: b.Kind, Name: b.Name}
+		switch b.Kind {
+		case guardiov1alpha1.ExtraBindingKindServiceAccount:
+			subject.Kind = rbacv1.ServiceAccountKind
+			subject.Namespace = b.Namespace
+			if subject.Namespace == "" {
+				subject.Namespace = ns
+			}
+		default:
+			subject.APIGroup = rbacv1.GroupName
 		}
-		rb.RoleRef = rbacv1.RoleRef{
-			APIGroup: rbacv1.GroupName,
-			Kind:     "ClusterRole",
-			Name:     "guardian-tenant-edit",
+		if err := ensureRoleBinding(ctx, c, ns, guardiov1alpha1.ExtraBindingName(b), subject, b.Role, spec); err != nil {
+			return err
 		}
-		return nil
-	})
-	return err
+	}
+	return nil
 }
 
-func ensureTenantAdminRoleBinding(ctx context.Context, c client.Client, ns string, spec BaselineSpec) error {
-	name := "guardian-tenant-admin.yaml"
-	adminGroup := spec.Tenant + ":ns-admin"
+// ensureServiceAccounts：ServiceAccount（imagePullSecrets 以 Tenant 为准），配置了 clusterRole 的再绑定 guardian-sa-<name>
+func ensureServiceAccounts(ctx context.Context, c client.Client, ns string, spec BaselineSpec) error {
+	for _, a := range selectServiceAccounts(spec.TenantObj, spec.Env) {
+		// 已有的同名 ServiceAccount（namespace 里的 default、租户自己建的）由 applyObject 拒绝覆盖
+		sa := &corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: a.Name, Namespace: ns}}
+		err := applyObject(ctx, c, sa, spec, func() error {
+			ensureBaselineMeta(&sa.ObjectMeta, spec)
+			var pull []corev1.LocalObjectReference
+			for _, s := range a.ImagePullSecrets {
+				pull = append(pull, corev1.LocalObjectReference{Name: s})
+			}
+			sa.ImagePullSecrets = pull
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		if role := strings.TrimSpace(a.ClusterRole); role != "" {
+			subject := rbacv1.Subject{Kind: rbacv1.ServiceAccountKind, Name: a.Name, Namespace: ns}
+			if err := ensureRoleBinding(ctx, c, ns, "guardian-sa-"+a.Name, subject, role, spec); err != nil {
+				return err
+			}
+		}
+		if spec.serviceAccounts != nil {
+			*spec.serviceAccounts = append(*spec.serviceAccounts, a.Name)
+		}
+	}
+	return nil
+}
+
+// ensureRoleBinding：subject -> ClusterRole 的 RoleBinding
+// roleRef 不可变，ClusterRole 变化时先删除旧的再重建；不属于本 request 的（不论 roleRef 是否相同）不覆盖，
+// 带 protect 注解的不删，直接报错
+func ensureRoleBinding(ctx context.Context, c client.Client, ns, name string, subject rbacv1.Subject, clusterRole string, spec BaselineSpec) error {
+	roleRef := rbacv1.RoleRef{
+		APIGroup: rbacv1.GroupName,
+		Kind:     "ClusterRole",
+		Name:     clusterRole,
+	}
+
+	var existing rbacv1.RoleBinding
+	err := c.Get(ctx, types.NamespacedName{Namespace: ns, Name: name}, &existing)
+	switch {
+	case err == nil && !mayOverwrite(&existing, "RoleBinding", spec):
+		return errNotManaged("RoleBinding", name, ns)
+	case err == nil && existing.RoleRef != roleRef && protected(&existing):
+		return permanent(fmt.Errorf("rolebinding %s in namespace %s is protected by %s, not replacing its roleRef %s with %s",
+			name, ns, guardiov1alpha1.AnnProtect, existing.RoleRef.Name, clusterRole))
+	case err == nil && existing.RoleRef != roleRef && spec.plan != nil:
+		// roleRef 不可修改，dry-run 无法模拟“删除再创建”，直接记为 Replace
+		recordPlan(spec, "RoleBinding", name, guardiov1alpha1.BaselineActionReplace)
+		return nil
+	case err == nil && existing.RoleRef != roleRef:
+		if err := c.Delete(ctx, &existing); err != nil && !apierrors.IsNotFound(err) {
+			return fmt.Errorf("delete rolebinding %s with stale roleRef: %w", name, err)
+		}
+	case err != nil && !apierrors.IsNotFound(err):
+		return err
+	}
 
 	rb := &rbacv1.RoleBinding{
 		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: ns},
 	}
-
-	_, err := controllerutil.CreateOrUpdate(ctx, c, rb, func() error {
+	err = applyObject(ctx, c, rb, spec, func() error {
 		ensureBaselineMeta(&rb.ObjectMeta, spec)
-		rb.Subjects = []rbacv1.Subject{
-			{
-				Kind:     rbacv1.GroupKind,
-				APIGroup: rbacv1.GroupName,
-				Name:     adminGroup,
-			},
-		}
-		rb.RoleRef = rbacv1.RoleRef{
-			APIGroup: rbacv1.GroupName,
-			Kind:     "ClusterRole",
-			Name:     "guardian-tenant-edit",
-		}
+		rb.Subjects = []rbacv1.Subject{subject}
+		rb.RoleRef = roleRef
 		return nil
 	})
 	return err
@@ -196,14 +542,17 @@ func ensureResourceQuota(ctx context.Context, c client.Client, ns string, spec B
 	name := "guardian-rq-default"
 	rq := &corev1.ResourceQuota{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: ns}}
 
-	_, err := controllerutil.CreateOrUpdate(ctx, c, rq, func() error {
+	err := applyObject(ctx, c, rq, spec, func() error {
 		ensureBaselineMeta(&rq.ObjectMeta, spec)
 
 		hard := corev1.ResourceList{}
 
 		// 1) 先用 Tenant 下发
 		if spec.TenantObj != nil {
-			q, ok := selectQuotaHard(spec.TenantObj, spec.Env)
+			q, ok, err := selectQuotaHard(spec.TenantObj, spec.Env, spec.Size)
+			if err != nil {
+				return err
+			}
 			if ok {
 				rl, err := quotaHardToResourceList(q)
 				if err != nil {
@@ -238,34 +587,42 @@ func defaultGlobalResourceQuota() corev1.ResourceList {
 	}
 }
 
+func defaultGlobalLimitRange() guardiov1alpha1.LimitRangeHard {
+	return guardiov1alpha1.LimitRangeHard{
+		DefaultRequestCPU:    "100m",
+		DefaultRequestMemory: "256Mi",
+		DefaultLimitCPU:      "1",
+		DefaultLimitMemory:   "1Gi",
+	}
+}
+
 func ensureLimitRange(ctx context.Context, c client.Client, ns string, spec BaselineSpec) error {
 	name := "guardian-lr-default"
 	lr := &corev1.LimitRange{
 		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: ns},
 	}
 
-	_, err := controllerutil.CreateOrUpdate(ctx, c, lr, func() error {
-		ensureBaselineMeta(&lr.ObjectMeta, spec)
+	item, err := limitRangeHardToItem(selectLimitRange(spec.TenantObj, spec.Env))
+	if err != nil {
+		return err
+	}
 
-		lr.Spec.Limits = []corev1.LimitRangeItem{
-			{
-				Type: corev1.LimitTypeContainer,
-				DefaultRequest: corev1.ResourceList{
-					corev1.ResourceCPU:    resource.MustParse("100m"),
-					corev1.ResourceMemory: resource.MustParse("256Mi"),
-				},
-				Default: corev1.ResourceList{
-					corev1.ResourceCPU:    resource.MustParse("1"),
-					corev1.ResourceMemory: resource.MustParse("1Gi"),
-				},
-			},
-		}
+	err = applyObject(ctx, c, lr, spec, func() error {
+		ensureBaselineMeta(&lr.ObjectMeta, spec)
+		lr.Spec.Limits = []corev1.LimitRangeItem{item}
 		return nil
 	})
 	return err
 }
 
 func ensureNetworkPolicies(ctx context.Context, c client.Client, ns string, spec BaselineSpec) error {
+	profile, cidrs := selectNetworkPolicy(spec.TenantObj, spec.Env)
+
+	// open：不做默认 deny，其余放行规则也就没有意义
+	if profile == guardiov1alpha1.NPProfileOpen {
+		return nil
+	}
+
 	// A) 默认 deny ingress+egress
 	if err := ensureNPDefaultDeny(ctx, c, ns, spec); err != nil {
 		return err
@@ -276,9 +633,18 @@ func ensureNetworkPolicies(ctx context.Context, c client.Client, ns string, spec
 		return err
 	}
 
-	// C) 允许同 namespace 内互通（常见 baseline，不然默认 deny 会导致同 ns 都不通）
-	if err := ensureNPAllowSameNamespace(ctx, c, ns, spec); err != nil {
-		return err
+	// C) standard：允许同 namespace 内互通（strict 不放行）
+	if profile == guardiov1alpha1.NPProfileStandard {
+		if err := ensureNPAllowSameNamespace(ctx, c, ns, spec); err != nil {
+			return err
+		}
+	}
+
+	// D) 允许访问指定的 egress CIDR
+	if len(cidrs) > 0 {
+		if err := ensureNPAllowEgressCIDRs(ctx, c, ns, cidrs, spec); err != nil {
+			return err
+		}
 	}
 
 	return nil
@@ -290,7 +656,7 @@ func ensureNPDefaultDeny(ctx context.Context, c client.Client, ns string, spec B
 		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: ns},
 	}
 
-	_, err := controllerutil.CreateOrUpdate(ctx, c, np, func() error {
+	err := applyObject(ctx, c, np, spec, func() error {
 		ensureBaselineMeta(&np.ObjectMeta, spec)
 		np.Spec.PodSelector = metav1.LabelSelector{} // all pods
 		np.Spec.PolicyTypes = []networkingv1.PolicyType{
@@ -310,7 +676,7 @@ func ensureNPAllowDNS(ctx context.Context, c client.Client, ns string, spec Base
 		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: ns},
 	}
 
-	_, err := controllerutil.CreateOrUpdate(ctx, c, np, func() error {
+	err := applyObject(ctx, c, np, spec, func() error {
 		ensureBaselineMeta(&np.ObjectMeta, spec)
 		np.Spec.PodSelector = metav1.LabelSelector{} // all pods
 		np.Spec.PolicyTypes = []networkingv1.PolicyType{
@@ -346,7 +712,7 @@ func ensureNPAllowSameNamespace(ctx context.Context, c client.Client, ns string,
 		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: ns},
 	}
 
-	_, err := controllerutil.CreateOrUpdate(ctx, c, np, func() error {
+	err := applyObject(ctx, c, np, spec, func() error {
 		ensureBaselineMeta(&np.ObjectMeta, spec)
 		np.Spec.PodSelector = metav1.LabelSelector{} // all pods
 		np.Spec.PolicyTypes = []networkingv1.PolicyType{
@@ -370,6 +736,38 @@ func ensureNPAllowSameNamespace(ctx context.Context, c client.Client, ns string,
 	return err
 }
 
+func ensureNPAllowEgressCIDRs(ctx context.Context, c client.Client, ns string, cidrs []string, spec BaselineSpec) error {
+	name := "guardian-np-allow-egress-cidrs"
+	np := &networkingv1.NetworkPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: ns},
+	}
+
+	peers := make([]networkingv1.NetworkPolicyPeer, 0, len(cidrs))
+	for _, cidr := range cidrs {
+		cidr = strings.TrimSpace(cidr)
+		if _, _, err := net.ParseCIDR(cidr); err != nil {
+			return permanent(fmt.Errorf("invalid allowEgressCIDRs entry %q: %w", cidr, err))
+		}
+		peers = append(peers, networkingv1.NetworkPolicyPeer{
+			IPBlock: &networkingv1.IPBlock{CIDR: cidr},
+		})
+	}
+
+	err := applyObject(ctx, c, np, spec, func() error {
+		ensureBaselineMeta(&np.ObjectMeta, spec)
+		np.Spec.PodSelector = metav1.LabelSelector{} // all pods
+		np.Spec.PolicyTypes = []networkingv1.PolicyType{
+			networkingv1.PolicyTypeEgress,
+		}
+		np.Spec.Ingress = nil
+		np.Spec.Egress = []networkingv1.NetworkPolicyEgressRule{
+			{To: peers},
+		}
+		return nil
+	})
+	return err
+}
+
 func mergeLabels(dst, src map[string]string) map[string]string {
 	out := map[string]string{}
 	for k, v := range dst {
@@ -397,5 +795,8 @@ func ensureBaselineMeta(obj metav1.Object, spec BaselineSpec) {
 	}
 	ann[guardiov1alpha1.AnnOwnerGroupRaw] = spec.OwnerGroup
 	ann[guardiov1alpha1.AnnRequestRaw] = spec.RequestName
+	for k, v := range spec.Requester {
+		ann[k] = v
+	}
 	obj.SetAnnotations(ann)
 }
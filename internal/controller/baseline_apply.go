@@ -0,0 +1,146 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	guardiov1alpha1 "github.com/CATDOGME/namespace-guardian/api/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/util/csaupgrade"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+)
+
+// FieldManager：baseline 对象 server-side apply 使用的 field manager
+const FieldManager = "namespace-guardian"
+
+// legacyFieldManagers：改用 server-side apply 之前 Update 写入时的 field manager（默认取二进制名 manager）
+var legacyFieldManagers = sets.New("manager")
+
+// applyObject：f 在空对象上填好期望的字段后用 server-side apply 写入，只声明 guardian 关心的字段，
+// 其他 controller / admission 插件设置的字段保持不变，也不会因为整字段覆盖而来回争抢。
+// 已有对象不属于本 request（且不在确认过的接管 plan 里）时不覆盖，返回终态错误。
+// 字段被别的 field manager 持有时：组件策略为 Force 则强制接管并记到 spec.conflicts，
+// 为 Report 则返回冲突错误（终态，由 condition 体现）。顺便记录实际发生变化的对象
+func applyObject(ctx context.Context, c client.Client, obj client.Object, spec BaselineSpec, f func() error) error {
+	if err := f(); err != nil {
+		return err
+	}
+	gvk, err := apiutil.GVKForObject(obj, c.Scheme())
+	if err != nil {
+		return err
+	}
+	raw, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+	if err != nil {
+		return err
+	}
+	desired := &unstructured.Unstructured{Object: raw}
+	desired.SetGroupVersionKind(gvk)
+	if spec.desired != nil {
+		spec.desired[gvk.Kind+"/"+obj.GetName()] = true
+	}
+	unstructured.RemoveNestedField(desired.Object, "metadata", "creationTimestamp")
+	unstructured.RemoveNestedField(desired.Object, "status")
+
+	// 现有对象直接读 apiserver（unstructured 不走 cache），和 apply 返回的结果比较，cache 滞后不会被当成变化
+	existing := &unstructured.Unstructured{}
+	existing.SetGroupVersionKind(gvk)
+	err = c.Get(ctx, client.ObjectKeyFromObject(obj), existing)
+	created := apierrors.IsNotFound(err)
+	if err != nil && !created {
+		return err
+	}
+	if !created {
+		if !mayOverwrite(existing, gvk.Kind, spec) {
+			return errNotManaged(gvk.Kind, obj.GetName(), obj.GetNamespace())
+		}
+		if err := upgradeManagedFields(ctx, c, existing); err != nil {
+			return fmt.Errorf("%s/%s: migrate managedFields: %w", gvk.Kind, obj.GetName(), err)
+		}
+	}
+	// 按确认过的接管 plan 覆盖的已有对象打上 taken-over 标记，不会被 prune；
+	// 之后每次 apply 都带上（不声明的字段会被 SSA 删掉）。guardian 创建的对象（含之前版本创建的）不打
+	if spec.takeOver[gvk.Kind+"/"+obj.GetName()] || (!created && takenOver(existing)) {
+		ann := desired.GetAnnotations()
+		if ann == nil {
+			ann = map[string]string{}
+		}
+		ann[guardiov1alpha1.AnnTakenOver] = "true"
+		desired.SetAnnotations(ann)
+	}
+
+	kind := gvk.Kind
+	err = c.Apply(ctx, client.ApplyConfigurationFromUnstructured(desired), client.FieldOwner(FieldManager))
+	if apierrors.IsConflict(err) {
+		if guardiov1alpha1.ConflictPolicyFor(tenantSpec(spec), conflictComponent(spec)) != guardiov1alpha1.ConflictPolicyForce {
+			return permanent(fmt.Errorf("%s/%s: %w", kind, obj.GetName(), err))
+		}
+		if spec.conflicts != nil {
+			spec.conflicts[spec.component] = append(spec.conflicts[spec.component],
+				fmt.Sprintf("%s/%s: %v", kind, obj.GetName(), err))
+		}
+		err = c.Apply(ctx, client.ApplyConfigurationFromUnstructured(desired), client.FieldOwner(FieldManager), client.ForceOwnership)
+	}
+	if err != nil {
+		return err
+	}
+
+	if !created && sameAppliedObject(existing.Object, desired.Object) {
+		return nil
+	}
+	if spec.changes != nil {
+		*spec.changes = append(*spec.changes, kind+"/"+obj.GetName())
+	}
+	if spec.plan != nil {
+		action := guardiov1alpha1.BaselineActionOverwrite
+		if created {
+			action = guardiov1alpha1.BaselineActionCreate
+		}
+		recordPlan(spec, kind, obj.GetName(), action)
+	}
+	return nil
+}
+
+// upgradeManagedFields：改用 server-side apply 之前，guardian 用 Update 写入的字段归在 legacyFieldManagers 名下，
+// 第一次 apply 会和自己的旧写入冲突（Report 策略直接失败），不再声明的字段也删不掉；
+// 先把这些 Update 条目并入 FieldManager 的 Apply 条目（已迁移的对象不发请求）
+func upgradeManagedFields(ctx context.Context, c client.Client, existing *unstructured.Unstructured) error {
+	patch, err := csaupgrade.UpgradeManagedFieldsPatch(existing, legacyFieldManagers, FieldManager)
+	if err != nil || patch == nil {
+		return err
+	}
+	return c.Patch(ctx, existing, client.RawPatch(types.JSONPatchType, patch))
+}
+
+// sameAppliedObject：apply 前后对象内容是否一致（忽略 apply 本身会改的元数据）
+func sameAppliedObject(before, after map[string]interface{}) bool {
+	strip := func(o map[string]interface{}) map[string]interface{} {
+		o = runtime.DeepCopyJSON(o)
+		for _, f := range [][]string{
+			{"apiVersion"}, {"kind"},
+			{"metadata", "managedFields"}, {"metadata", "resourceVersion"}, {"metadata", "generation"},
+		} {
+			unstructured.RemoveNestedField(o, f...)
+		}
+		return o
+	}
+	return equality.Semantic.DeepEqual(strip(before), strip(after))
+}
+
+// conflictComponent：condition 类型（如 RBACReady）对应 Tenant baseline.conflicts 里的组件名（RBAC）
+func conflictComponent(spec BaselineSpec) string {
+	return strings.TrimSuffix(spec.component, "Ready")
+}
+
+func tenantSpec(spec BaselineSpec) *guardiov1alpha1.TenantSpec {
+	if spec.TenantObj == nil {
+		return nil
+	}
+	return &spec.TenantObj.Spec
+}
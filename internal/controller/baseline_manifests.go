@@ -0,0 +1,188 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	guardiov1alpha1 "github.com/CATDOGME/namespace-guardian/api/v1alpha1"
+	networkingv1 "k8s.io/api/networking/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+func selectManifests(t *guardiov1alpha1.Tenant, env string) []guardiov1alpha1.BaselineManifest {
+	if t == nil || t.Spec.Baseline == nil {
+		return nil
+	}
+	var out []guardiov1alpha1.BaselineManifest
+	for _, m := range t.Spec.Baseline.Manifests {
+		if guardiov1alpha1.ManifestApplies(m, env) {
+			out = append(out, m)
+		}
+	}
+	return out
+}
+
+// ensureManifests：按模板渲染 Tenant baseline.manifests 并逐个 server-side apply（unstructured，适用于任意 kind）
+// 单个对象失败不影响其他对象，逐个结果写到 spec.manifests；
+// 上次下发、本次不再需要的对象删除（dry-run 时不清理），删除失败的保留在结果里下次再删
+func ensureManifests(ctx context.Context, c client.Client, ns string, spec BaselineSpec) error {
+	var results []guardiov1alpha1.ManifestStatus
+	var firstErr error
+	for _, m := range selectManifests(spec.TenantObj, spec.Env) {
+		st, err := ensureManifest(ctx, c, ns, m, spec)
+		results = append(results, st)
+		if err != nil && firstErr == nil {
+			firstErr = fmt.Errorf("manifest %s: %w", m.Name, err)
+		}
+	}
+
+	if spec.plan == nil {
+		stale, err := pruneManifests(ctx, c, ns, spec, results)
+		results = append(results, stale...)
+		if err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	if spec.manifests != nil {
+		*spec.manifests = results
+	}
+	return firstErr
+}
+
+func ensureManifest(ctx context.Context, c client.Client, ns string, m guardiov1alpha1.BaselineManifest, spec BaselineSpec) (guardiov1alpha1.ManifestStatus, error) {
+	st := guardiov1alpha1.ManifestStatus{Name: m.Name}
+	fail := func(err error) (guardiov1alpha1.ManifestStatus, error) {
+		st.Message = err.Error()
+		return st, err
+	}
+
+	desired, err := guardiov1alpha1.RenderManifest(m.Template, spec.Tenant, spec.Env, ns, spec.OwnerGroup)
+	if err != nil {
+		return fail(permanent(err))
+	}
+	st.APIVersion, st.Kind, st.ObjectName = desired.GetAPIVersion(), desired.GetKind(), desired.GetName()
+	desired.SetNamespace(ns)
+	namespaced, err := c.IsObjectNamespaced(desired)
+	if err != nil {
+		return fail(err)
+	}
+	if !namespaced {
+		return fail(permanent(fmt.Errorf("%s is cluster-scoped, only namespaced objects are allowed", st.Kind)))
+	}
+
+	err = applyObject(ctx, c, desired, spec, func() error {
+		ensureBaselineMeta(desired, spec)
+		return nil
+	})
+	if err != nil {
+		return fail(err)
+	}
+	st.Applied = true
+	return st, nil
+}
+
+// pruneManifests：删除上次下发、这次不再需要的对象（manifest 被移除，或渲染出的对象换了 kind/name）
+// 渲染失败的 manifest 不清理旧对象；只删除带本 request 标签、不是接管来的且没有 protect 注解的对象
+func pruneManifests(ctx context.Context, c client.Client, ns string, spec BaselineSpec,
+	results []guardiov1alpha1.ManifestStatus) ([]guardiov1alpha1.ManifestStatus, error) {
+	current := map[string]guardiov1alpha1.ManifestStatus{}
+	for _, r := range results {
+		current[r.Name] = r
+	}
+
+	var stale []guardiov1alpha1.ManifestStatus
+	var firstErr error
+	for _, p := range spec.PreviousManifests {
+		if !p.Applied || p.Kind == "" {
+			continue
+		}
+		if r, ok := current[p.Name]; ok && (r.Kind == "" || sameManifestObject(r, p)) {
+			continue
+		}
+
+		obj := &unstructured.Unstructured{}
+		obj.SetAPIVersion(p.APIVersion)
+		obj.SetKind(p.Kind)
+		err := c.Get(ctx, types.NamespacedName{Namespace: ns, Name: p.ObjectName}, obj)
+		if err == nil {
+			if obj.GetLabels()[guardiov1alpha1.LabelRequestHash] != guardiov1alpha1.ShortHash16(spec.RequestName) ||
+				protected(obj) || takenOver(obj) {
+				continue
+			}
+			err = c.Delete(ctx, obj)
+		}
+		if err != nil && !apierrors.IsNotFound(err) {
+			p.Message = fmt.Sprintf("prune failed: %v", err)
+			stale = append(stale, p)
+			if firstErr == nil {
+				firstErr = fmt.Errorf("prune %s %s: %w", p.Kind, p.ObjectName, err)
+			}
+		}
+	}
+	return stale, firstErr
+}
+
+func sameManifestObject(a, b guardiov1alpha1.ManifestStatus) bool {
+	return a.APIVersion == b.APIVersion && a.Kind == b.Kind && a.ObjectName == b.ObjectName
+}
+
+// manifestWatcher：manifests 的 kind 由 Tenant 决定，启动时不知道要 Watch 哪些；
+// 第一次下发某个 kind 后按需加一个只取 metadata 的 Watch，对象被改/被删时重新下发（漂移修复）
+// 需要该 kind 的 list/watch 权限（见 config/rbac/manifest_objects.yaml）
+type manifestWatcher struct {
+	controller controller.Controller
+	cache      cache.Cache
+
+	mu      sync.Mutex
+	watched map[schema.GroupVersionKind]bool
+}
+
+func (w *manifestWatcher) watch(results []guardiov1alpha1.ManifestStatus) error {
+	if w == nil {
+		return nil
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	for _, r := range results {
+		if !r.Applied || r.Kind == "" {
+			continue
+		}
+		gvk := schema.FromAPIVersionAndKind(r.APIVersion, r.Kind)
+		if w.watched[gvk] || staticallyWatched(gvk) {
+			continue
+		}
+		obj := &metav1.PartialObjectMetadata{}
+		obj.SetGroupVersionKind(gvk)
+		err := w.controller.Watch(source.Kind(w.cache, client.Object(obj),
+			handler.EnqueueRequestsFromMapFunc(requestForBaselineObject), baselineObjectPredicate))
+		if err != nil {
+			return fmt.Errorf("watch %s: %w", gvk, err)
+		}
+		w.watched[gvk] = true
+	}
+	return nil
+}
+
+// staticallyWatched：SetupWithManager 里已有 Watch 的 kind；Secret/ConfigMap 的 cache 只放 propagation 对象，
+// 不为 manifests 另开 Watch（同名 kind 的 metadata 全量缓存违背按标签缓存的初衷），这两类在下次 reconcile 时修复
+func staticallyWatched(gvk schema.GroupVersionKind) bool {
+	switch gvk.GroupKind() {
+	case schema.GroupKind{Group: rbacv1.GroupName, Kind: "RoleBinding"},
+		schema.GroupKind{Kind: "ServiceAccount"}, schema.GroupKind{Kind: "ResourceQuota"}, schema.GroupKind{Kind: "LimitRange"},
+		schema.GroupKind{Kind: "Secret"}, schema.GroupKind{Kind: "ConfigMap"},
+		schema.GroupKind{Group: networkingv1.GroupName, Kind: "NetworkPolicy"}:
+		return true
+	}
+	return false
+}
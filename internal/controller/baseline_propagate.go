@@ -0,0 +1,208 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	guardiov1alpha1 "github.com/CATDOGME/namespace-guardian/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func selectPropagate(t *guardiov1alpha1.Tenant) guardiov1alpha1.TenantPropagateSpec {
+	if t == nil || t.Spec.Baseline == nil || t.Spec.Baseline.Propagate == nil {
+		return guardiov1alpha1.TenantPropagateSpec{}
+	}
+	return *t.Spec.Baseline.Propagate
+}
+
+// ensurePropagatedObjects：把 Tenant baseline.propagate 引用的 Secret/ConfigMap 复制进 namespace（同名），
+// 来源变化时经 Watch 重新同步；不再引用的复制品删除（dry-run 时不清理）
+func ensurePropagatedObjects(ctx context.Context, c client.Client, ns string, spec BaselineSpec) error {
+	p := selectPropagate(spec.TenantObj)
+
+	secrets := map[string]bool{}
+	for _, ref := range p.Secrets {
+		srcNS, name, ok := guardiov1alpha1.SplitObjectRef(ref)
+		if !ok {
+			return permanent(fmt.Errorf("invalid propagate reference %q", ref))
+		}
+		if srcNS == ns {
+			continue // 来源就在本 namespace
+		}
+		secrets[name] = true
+		if err := ensurePropagatedSecret(ctx, c, ns, srcNS, name, spec); err != nil {
+			return err
+		}
+	}
+
+	configMaps := map[string]bool{}
+	for _, ref := range p.ConfigMaps {
+		srcNS, name, ok := guardiov1alpha1.SplitObjectRef(ref)
+		if !ok {
+			return permanent(fmt.Errorf("invalid propagate reference %q", ref))
+		}
+		if srcNS == ns {
+			continue
+		}
+		configMaps[name] = true
+		if err := ensurePropagatedConfigMap(ctx, c, ns, srcNS, name, spec); err != nil {
+			return err
+		}
+	}
+
+	if spec.plan != nil {
+		return nil
+	}
+	return prunePropagated(ctx, c, ns, spec, secrets, configMaps)
+}
+
+// ensurePropagatedSecret：type 不可修改，来源 type 变化时先删除旧的复制品再重建
+func ensurePropagatedSecret(ctx context.Context, c client.Client, ns, srcNS, name string, spec BaselineSpec) error {
+	var src corev1.Secret
+	if err := getPropagationSource(ctx, c, srcNS, name, &src); err != nil {
+		return err
+	}
+
+	var existing corev1.Secret
+	err := c.Get(ctx, types.NamespacedName{Namespace: ns, Name: name}, &existing)
+	switch {
+	case err == nil && !mayOverwrite(&existing, "Secret", spec):
+		return errNotManaged("Secret", name, ns)
+	case err == nil && existing.Type != src.Type && spec.plan != nil:
+		recordPlan(spec, "Secret", name, guardiov1alpha1.BaselineActionReplace)
+		return nil
+	case err == nil && existing.Type != src.Type:
+		if err := c.Delete(ctx, &existing); err != nil && !apierrors.IsNotFound(err) {
+			return fmt.Errorf("delete secret %s with stale type: %w", name, err)
+		}
+	case err != nil && !apierrors.IsNotFound(err):
+		return err
+	}
+
+	dst := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: ns}}
+	return applyObject(ctx, c, dst, spec, func() error {
+		ensureBaselineMeta(&dst.ObjectMeta, spec)
+		dst.Labels[guardiov1alpha1.LabelPropagation] = guardiov1alpha1.PropagationCopy
+		dst.Annotations[guardiov1alpha1.AnnPropagatedFrom] = srcNS + "/" + name
+		dst.Type = src.Type
+		dst.Data = src.Data
+		return nil
+	})
+}
+
+func ensurePropagatedConfigMap(ctx context.Context, c client.Client, ns, srcNS, name string, spec BaselineSpec) error {
+	var src corev1.ConfigMap
+	if err := getPropagationSource(ctx, c, srcNS, name, &src); err != nil {
+		return err
+	}
+
+	dst := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: ns}}
+	return applyObject(ctx, c, dst, spec, func() error {
+		ensureBaselineMeta(&dst.ObjectMeta, spec)
+		dst.Labels[guardiov1alpha1.LabelPropagation] = guardiov1alpha1.PropagationCopy
+		dst.Annotations[guardiov1alpha1.AnnPropagatedFrom] = srcNS + "/" + name
+		dst.Data = src.Data
+		dst.BinaryData = src.BinaryData
+		return nil
+	})
+}
+
+// getPropagationSource：来源须带 guardian.io/propagation=source 标签（平台显式允许复制），
+// 避免 Tenant 引用 kube-system 等 namespace 里的任意 Secret 把它复制进租户 namespace
+func getPropagationSource(ctx context.Context, c client.Client, srcNS, name string, src client.Object) error {
+	kind := objectKind(c, src)
+	err := c.Get(ctx, types.NamespacedName{Namespace: srcNS, Name: name}, src)
+	if apierrors.IsNotFound(err) {
+		return permanent(fmt.Errorf("source %s %s/%s not found", kind, srcNS, name))
+	}
+	if err != nil {
+		return fmt.Errorf("get source %s %s/%s: %w", kind, srcNS, name, err)
+	}
+	if src.GetLabels()[guardiov1alpha1.LabelPropagation] != guardiov1alpha1.PropagationSource {
+		return permanent(fmt.Errorf("source %s %s/%s is not labeled %s=%s", kind, srcNS, name,
+			guardiov1alpha1.LabelPropagation, guardiov1alpha1.PropagationSource))
+	}
+	return nil
+}
+
+// prunePropagated：删除本 request 名下、带 propagated-from 注解但已不在 Tenant 引用列表里的复制品
+func prunePropagated(ctx context.Context, c client.Client, ns string, spec BaselineSpec, secrets, configMaps map[string]bool) error {
+	opts := []client.ListOption{client.InNamespace(ns), client.MatchingLabels{
+		guardiov1alpha1.LabelManaged:     "true",
+		guardiov1alpha1.LabelRequestHash: guardiov1alpha1.ShortHash16(spec.RequestName),
+	}}
+
+	var sl corev1.SecretList
+	if err := c.List(ctx, &sl, opts...); err != nil {
+		return err
+	}
+	for i := range sl.Items {
+		if err := prunePropagatedObject(ctx, c, &sl.Items[i], secrets); err != nil {
+			return err
+		}
+	}
+
+	var cl corev1.ConfigMapList
+	if err := c.List(ctx, &cl, opts...); err != nil {
+		return err
+	}
+	for i := range cl.Items {
+		if err := prunePropagatedObject(ctx, c, &cl.Items[i], configMaps); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func prunePropagatedObject(ctx context.Context, c client.Client, obj client.Object, desired map[string]bool) error {
+	if _, ok := obj.GetAnnotations()[guardiov1alpha1.AnnPropagatedFrom]; !ok || desired[obj.GetName()] || protected(obj) {
+		return nil
+	}
+	if err := c.Delete(ctx, obj); err != nil && !apierrors.IsNotFound(err) {
+		return fmt.Errorf("prune %s %s: %w", objectKind(c, obj), obj.GetName(), err)
+	}
+	return nil
+}
+
+// propagationObjectPredicate：只关心带 guardian.io/propagation 标签的 Secret/ConfigMap（来源与复制品）
+// manager 的 cache 也按同一标签过滤，见 cmd/main.go
+var propagationObjectPredicate = predicate.NewPredicateFuncs(func(obj client.Object) bool {
+	_, ok := obj.GetLabels()[guardiov1alpha1.LabelPropagation]
+	return ok
+})
+
+// requestsForPropagationSource：
+// - 复制品被改/被删：按 baseline 对象映射回 NamespaceRequest（漂移修复）
+// - 来源对象变化：找到引用它的 Tenant，把该 tenant 下所有 NamespaceRequest 重新入队
+func (r *NamespaceRequestReconciler) requestsForPropagationSource(ctx context.Context, obj client.Object) []reconcile.Request {
+	if obj.GetLabels()[guardiov1alpha1.LabelPropagation] != guardiov1alpha1.PropagationSource {
+		return requestForBaselineObject(ctx, obj)
+	}
+
+	var tenants guardiov1alpha1.TenantList
+	if err := r.List(ctx, &tenants); err != nil {
+		log.FromContext(ctx).Error(err, "list tenants for propagation source failed")
+		return nil
+	}
+	ref := obj.GetNamespace() + "/" + obj.GetName()
+	var out []reconcile.Request
+	for i := range tenants.Items {
+		p := selectPropagate(&tenants.Items[i])
+		refs := p.ConfigMaps
+		if _, ok := obj.(*corev1.Secret); ok {
+			refs = p.Secrets
+		}
+		if slices.Contains(refs, ref) {
+			out = append(out, r.requestsForTenant(ctx, &tenants.Items[i])...)
+		}
+	}
+	return out
+}
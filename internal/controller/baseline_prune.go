@@ -0,0 +1,113 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	guardiov1alpha1 "github.com/CATDOGME/namespace-guardian/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// prunedKinds：pruneBaseline 统一清理的对象类型，清理失败时报到对应组件的 condition
+// Secret/ConfigMap（propagate）与 manifests 按各自的来源清理，不在这里
+var prunedKinds = []struct {
+	newList   func() client.ObjectList
+	component string
+}{
+	{func() client.ObjectList { return &rbacv1.RoleBindingList{} }, guardiov1alpha1.CondRBACReady},
+	{func() client.ObjectList { return &corev1.ServiceAccountList{} }, guardiov1alpha1.CondServiceAccountsReady},
+	{func() client.ObjectList { return &corev1.ResourceQuotaList{} }, guardiov1alpha1.CondQuotaReady},
+	{func() client.ObjectList { return &corev1.LimitRangeList{} }, guardiov1alpha1.CondLimitRangeReady},
+	{func() client.ObjectList { return &networkingv1.NetworkPolicyList{} }, guardiov1alpha1.CondNetworkPolicyReady},
+}
+
+// pruneBaseline：删除 namespace 内带 managed + 本 request hash 标签、但这次没有下发（不在 spec.desired 里）的对象，
+// 接管来的已有对象（guardian.io/taken-over）与带 guardian.io/protect=true 的保留；只在所有组件都下发成功后调用，dry-run 时不清理
+func pruneBaseline(ctx context.Context, c client.Client, ns string, spec BaselineSpec) error {
+	if spec.plan != nil || spec.desired == nil {
+		return nil
+	}
+	for _, k := range prunedKinds {
+		if err := pruneKind(ctx, c, ns, k.newList(), spec); err != nil {
+			return &BaselineError{k.component, err}
+		}
+	}
+	return nil
+}
+
+func pruneKind(ctx context.Context, c client.Client, ns string, list client.ObjectList, spec BaselineSpec) error {
+	if err := c.List(ctx, list, client.InNamespace(ns), client.MatchingLabels{
+		guardiov1alpha1.LabelManaged:     "true",
+		guardiov1alpha1.LabelRequestHash: guardiov1alpha1.ShortHash16(spec.RequestName),
+	}); err != nil {
+		return err
+	}
+	items, err := meta.ExtractList(list)
+	if err != nil {
+		return err
+	}
+	for _, item := range items {
+		obj, ok := item.(client.Object)
+		if !ok {
+			continue
+		}
+		kind := objectKind(c, obj)
+		if spec.desired[kind+"/"+obj.GetName()] || protected(obj) || takenOver(obj) {
+			continue
+		}
+		if err := c.Delete(ctx, obj); err != nil && !apierrors.IsNotFound(err) {
+			return fmt.Errorf("prune %s %s: %w", kind, obj.GetName(), err)
+		}
+	}
+	return nil
+}
+
+// protected：对象打了 guardian.io/protect=true，任何清理（prune、版本迁移）都跳过
+func protected(obj client.Object) bool {
+	return obj.GetAnnotations()[guardiov1alpha1.AnnProtect] == "true"
+}
+
+// takenOver：对象是下发时接管的已有对象（而不是 guardian 创建的），prune 不删
+func takenOver(obj client.Object) bool {
+	return obj.GetAnnotations()[guardiov1alpha1.AnnTakenOver] == "true"
+}
+
+// ownedByRequest：对象带 managed 标签与本 request 的 hash（由本 request 下发），其他对象不覆盖、不删除
+func ownedByRequest(obj client.Object, spec BaselineSpec) bool {
+	l := obj.GetLabels()
+	return l[guardiov1alpha1.LabelManaged] == "true" && l[guardiov1alpha1.LabelRequestHash] == guardiov1alpha1.ShortHash16(spec.RequestName)
+}
+
+// mayOverwrite：已有对象能否被覆盖——本 request 下发的（含标签被删改、按 drift 修复的，见 recordedByRequest），
+// 或接管 namespace 时确认过的 plan 里列出的；dry-run 不拦，记到 plan 里等人确认。所有 baseline 对象共用这一条规则
+func mayOverwrite(obj client.Object, kind string, spec BaselineSpec) bool {
+	return ownedByRequest(obj, spec) || recordedByRequest(obj, kind, spec) ||
+		spec.plan != nil || spec.takeOver[kind+"/"+obj.GetName()]
+}
+
+// fixedNameKinds：guardian 自己命名（guardian- 前缀）的 baseline 对象类型
+var fixedNameKinds = sets.New("RoleBinding", "ResourceQuota", "LimitRange", "NetworkPolicy")
+
+// recordedByRequest：request 已在这个 namespace 下发过时，status 里记录的对象，
+// 以及仍带本 request hash 标签的 guardian 固定命名对象（同名的外来对象不算）
+func recordedByRequest(obj client.Object, kind string, spec BaselineSpec) bool {
+	if spec.recorded == nil {
+		return false
+	}
+	if spec.recorded[kind+"/"+obj.GetName()] {
+		return true
+	}
+	return fixedNameKinds.Has(kind) && strings.HasPrefix(obj.GetName(), "guardian-") &&
+		obj.GetLabels()[guardiov1alpha1.LabelRequestHash] == guardiov1alpha1.ShortHash16(spec.RequestName)
+}
+
+func errNotManaged(kind, name, ns string) error {
+	return permanent(fmt.Errorf("%s %s already exists in namespace %s and is not managed by this request", strings.ToLower(kind), name, ns))
+}
@@ -0,0 +1,100 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	guardiov1alpha1 "github.com/CATDOGME/namespace-guardian/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// baselineMigrations：从 key 版本迁到下一个版本后不再使用的对象（改名前的旧名称、被删除的组件）
+// 新版本的对象由 EnsureBaseline 先按新版本下发，迁移只负责删除旧对象
+var baselineMigrations = map[string][]func(ns string) client.Object{
+	// v1 -> v2：tenant admin RoleBinding 去掉误带的 .yaml 后缀
+	guardiov1alpha1.BaselineVersionV1: {
+		func(ns string) client.Object {
+			return &rbacv1.RoleBinding{ObjectMeta: metav1.ObjectMeta{Name: "guardian-tenant-admin.yaml", Namespace: ns}}
+		},
+	},
+}
+
+func tenantAdminRoleBindingName(version string) string {
+	if version == guardiov1alpha1.BaselineVersionV1 {
+		return "guardian-tenant-admin.yaml"
+	}
+	return "guardian-tenant-admin"
+}
+
+// baselineVersions：返回本次渲染用的版本与 namespace 上已记录的版本
+// namespace 已经在更新的版本时按 namespace 上的版本渲染，不回退
+func baselineVersions(ctx context.Context, c client.Client, ns string, spec BaselineSpec) (render, applied string, err error) {
+	var namespace corev1.Namespace
+	if err := c.Get(ctx, types.NamespacedName{Name: ns}, &namespace); err != nil {
+		return "", "", err
+	}
+	applied = guardiov1alpha1.AppliedBaselineVersion(namespace.Annotations)
+	render = spec.Version
+	if render == "" {
+		render = guardiov1alpha1.BaselineVersionV1
+	}
+	if guardiov1alpha1.CompareBaselineVersions(applied, render) > 0 {
+		render = applied
+	}
+	return render, applied, nil
+}
+
+// migrateBaseline：从 from 逐个版本迁到 to，删除每一步遗留的旧对象（只删带本 request 标签、没有 protect 注解的），
+// 全部完成后在 namespace 上记录版本；dry-run 时不做任何事
+func migrateBaseline(ctx context.Context, c client.Client, ns, from, to string, spec BaselineSpec) error {
+	if spec.plan != nil {
+		return nil
+	}
+	for i := range guardiov1alpha1.BaselineVersions {
+		v := guardiov1alpha1.BaselineVersions[i]
+		if guardiov1alpha1.CompareBaselineVersions(v, from) < 0 || guardiov1alpha1.CompareBaselineVersions(v, to) >= 0 {
+			continue
+		}
+		for _, newObj := range baselineMigrations[v] {
+			if err := deleteObsolete(ctx, c, newObj(ns), spec); err != nil {
+				return fmt.Errorf("migrate baseline %s -> %s: %w", v, guardiov1alpha1.BaselineVersions[i+1], err)
+			}
+		}
+	}
+
+	var namespace corev1.Namespace
+	if err := c.Get(ctx, types.NamespacedName{Name: ns}, &namespace); err != nil {
+		return err
+	}
+	if namespace.Annotations[guardiov1alpha1.AnnBaselineVersion] == to {
+		return nil
+	}
+	patch := client.MergeFrom(namespace.DeepCopy())
+	if namespace.Annotations == nil {
+		namespace.Annotations = map[string]string{}
+	}
+	namespace.Annotations[guardiov1alpha1.AnnBaselineVersion] = to
+	return c.Patch(ctx, &namespace, patch)
+}
+
+func deleteObsolete(ctx context.Context, c client.Client, obj client.Object, spec BaselineSpec) error {
+	err := c.Get(ctx, client.ObjectKeyFromObject(obj), obj)
+	if apierrors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if obj.GetLabels()[guardiov1alpha1.LabelRequestHash] != guardiov1alpha1.ShortHash16(spec.RequestName) || protected(obj) {
+		return nil // 不是本 request 下发的，或标记了保护，保留
+	}
+	if err := c.Delete(ctx, obj); err != nil && !apierrors.IsNotFound(err) {
+		return fmt.Errorf("delete %s %s: %w", objectKind(c, obj), obj.GetName(), err)
+	}
+	return nil
+}
@@ -0,0 +1,88 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	guardiov1alpha1 "github.com/CATDOGME/namespace-guardian/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// unmanagedNamespace：既不受 guardian 管理、也不是 orphaned 的 namespace（手工创建的）
+func unmanagedNamespace(ns *corev1.Namespace) bool {
+	return ns.Labels[guardiov1alpha1.LabelManaged] != "true" && ns.Labels[guardiov1alpha1.LabelOrphaned] != "true"
+}
+
+// adoptionPlanHash：plan 的指纹，确认注解必须与之一致，plan 变化后旧的确认自动失效
+func adoptionPlanHash(plan []guardiov1alpha1.BaselineObjectChange) string {
+	lines := make([]string, 0, len(plan))
+	for _, c := range plan {
+		lines = append(lines, c.Action+" "+c.Kind+"/"+c.Name)
+	}
+	return guardiov1alpha1.ShortHash16(strings.Join(lines, "\n"))
+}
+
+// adoptedObjects：确认过的 plan 里要覆盖/替换的已有对象（Kind/name）
+func adoptedObjects(plan []guardiov1alpha1.BaselineObjectChange) map[string]bool {
+	out := map[string]bool{}
+	for _, c := range plan {
+		if c.Action != guardiov1alpha1.BaselineActionCreate {
+			out[c.Kind+"/"+c.Name] = true
+		}
+	}
+	return out
+}
+
+// reconcileAdoption 处理 spec.adopt：对未受管理的 namespace 先 dry-run 出 baseline 变更写到 status.adoption，
+// adopt-confirm 注解与 planHash 一致才放行；返回 false 时调用方结束本次 reconcile
+func (r *NamespaceRequestReconciler) reconcileAdoption(ctx context.Context, nr *guardiov1alpha1.NamespaceRequest,
+	spec BaselineSpec, old *guardiov1alpha1.NamespaceRequestStatus) (bool, error) {
+	nsName := strings.TrimSpace(nr.Spec.Adopt)
+
+	var ns corev1.Namespace
+	if err := r.Get(ctx, types.NamespacedName{Name: nsName}, &ns); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return false, err
+		}
+		msg := fmt.Sprintf("namespace %q to adopt does not exist", nsName)
+		setCondition(nr, guardiov1alpha1.CondNamespaceReady, metav1.ConditionFalse, "AdoptNamespaceNotFound", msg)
+		return false, r.setStatusFailed(ctx, nr, old, "AdoptNamespaceNotFound", msg)
+	}
+
+	// 已归属本 request（接管完成但 status 还没写回）：按普通流程继续
+	if claimableBy(&ns, nr) {
+		return true, nil
+	}
+	if !unmanagedNamespace(&ns) {
+		msg := fmt.Sprintf("namespace %q is already managed by tenant=%q request=%q", nsName,
+			ns.Labels[guardiov1alpha1.LabelTenant], ns.Labels[guardiov1alpha1.LabelRequest])
+		setCondition(nr, guardiov1alpha1.CondNamespaceReady, metav1.ConditionFalse, "NamespaceConflict", msg)
+		return false, r.setStatusFailed(ctx, nr, old, "NamespaceConflict", msg)
+	}
+
+	plan, err := PlanBaseline(ctx, r.Client, nsName, spec)
+	if err != nil {
+		setBaselineConditions(nr, err, nil)
+		return false, r.setStatusFailed(ctx, nr, old, "AdoptPlanFailed", err.Error())
+	}
+	plan = append([]guardiov1alpha1.BaselineObjectChange{
+		{Kind: "Namespace", Name: nsName, Action: guardiov1alpha1.BaselineActionOverwrite},
+	}, plan...)
+	hash := adoptionPlanHash(plan)
+	nr.Status.Adoption = &guardiov1alpha1.AdoptionStatus{Plan: plan, PlanHash: hash}
+
+	if nr.Annotations[guardiov1alpha1.AnnAdoptConfirm] == hash {
+		return true, nil
+	}
+
+	msg := fmt.Sprintf("review status.adoption.plan (%d changes) and annotate %s=%s to adopt namespace %q",
+		len(plan), guardiov1alpha1.AnnAdoptConfirm, hash, nsName)
+	nr.Status.Phase = guardiov1alpha1.PhaseAdoptionPending
+	nr.Status.Reason = "AdoptionPending"
+	nr.Status.Message = msg
+	return false, r.updateStatus(ctx, nr, old)
+}
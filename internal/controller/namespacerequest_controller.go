@@ -2,34 +2,56 @@ package controller
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"k8s.io/apimachinery/pkg/runtime"
-	"regexp"
 	"strings"
 
 	guardiov1alpha1 "github.com/CATDOGME/namespace-guardian/api/v1alpha1"
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
 
 // RBAC（阶段1最小集合）
 // +kubebuilder:rbac:groups=guardian.guardian.io,resources=namespacerequests,verbs=get;list;watch;update;patch
 // +kubebuilder:rbac:groups=guardian.guardian.io,resources=namespacerequests/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=guardian.guardian.io,resources=namespacerequests/finalizers,verbs=update
 // +kubebuilder:rbac:groups=guardian.guardian.io,resources=tenants,verbs=get;list;watch
-// +kubebuilder:rbac:groups="",resources=namespaces,verbs=get;list;watch;create;update;patch
+// +kubebuilder:rbac:groups="",resources=namespaces,verbs=get;list;watch;create;update;patch;delete
 
-// +kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=rolebindings,verbs=get;list;watch;create;update;patch
-// +kubebuilder:rbac:groups="",resources=resourcequotas;limitranges,verbs=get;list;watch;create;update;patch
-// +kubebuilder:rbac:groups=networking.k8s.io,resources=networkpolicies,verbs=get;list;watch;create;update;patch
+// +kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=rolebindings,verbs=get;list;watch;create;update;patch;delete
+// clusterroles 只读（role catalog 标签查询）；bind 只在 config/rbac/binder.yaml 里按 resourceNames 授予
+// +kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=clusterroles,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=resourcequotas;limitranges,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups="",resources=serviceaccounts,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups="",resources=secrets;configmaps,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=networking.k8s.io,resources=networkpolicies,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
 
 type NamespaceRequestReconciler struct {
 	client.Client
-	Scheme *runtime.Scheme
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
+
+	// manifestWatches 由 SetupWithManager 设置（单测直接构造的 reconciler 没有，不加 Watch）
+	manifestWatches *manifestWatcher
 }
 
 func (r *NamespaceRequestReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
@@ -40,83 +62,288 @@ func (r *NamespaceRequestReconciler) Reconcile(ctx context.Context, req ctrl.Req
 		return ctrl.Result{}, client.IgnoreNotFound(err)
 	}
 
-	// 删除场景：阶段1不处理回收，直接忽略
+	// 删除场景：按 Tenant reclaim 策略回收 namespace
 	if !nr.DeletionTimestamp.IsZero() {
-		return ctrl.Result{}, nil
+		return r.reconcileDelete(ctx, &nr)
 	}
 
-	// 若已经 Provisioned，保持幂等（也可以做“确保 namespace 存在”）
-	if nr.Status.Phase == guardiov1alpha1.PhaseProvisioned && nr.Status.NamespaceName != "" {
-		return ctrl.Result{}, nil
+	// guardian.io/retry：手动重试，消费掉注解并清空退避状态（与 finalizer 一起写回）
+	_, manualRetry := nr.Annotations[guardiov1alpha1.AnnRetry]
+	if manualRetry {
+		delete(nr.Annotations, guardiov1alpha1.AnnRetry)
+	}
+	if controllerutil.AddFinalizer(&nr, guardiov1alpha1.FinalizerNamespaceRequest) || manualRetry {
+		if err := r.Update(ctx, &nr); err != nil {
+			return ctrl.Result{}, err
+		}
 	}
 
-	tenant := strings.TrimSpace(nr.Spec.Tenant)
-	env := strings.TrimSpace(nr.Spec.Env)
-	if env == "" {
-		env = "dev"
+	// status 快照：写回时与之比较，为每次 phase/condition 变化记录 Event
+	oldStatus := nr.Status.DeepCopy()
+	if manualRetry {
+		nr.Status.Retry = nil
+		r.Recorder.Event(&nr, corev1.EventTypeNormal, "RetryRequested", "manual retry requested")
 	}
 
+	tenant := strings.TrimSpace(nr.Spec.Tenant)
+	env := requestEnv(&nr)
+
 	// 校验 Tenant 是否存在（阶段1用 controller 做基本校验；阶段2会移到 webhook）
-	if err := r.ensureTenantExists(ctx, tenant); err != nil {
+	t, err := r.getTenant(ctx, tenant)
+	if err != nil && tenant != "" && !apierrors.IsNotFound(err) {
+		// 读 Tenant 失败（apiserver 不可用等）：退避重试
+		l.Error(err, "get tenant failed", "tenant", tenant)
+		setCondition(&nr, guardiov1alpha1.CondTenantValid, metav1.ConditionUnknown, "TenantLookupFailed", err.Error())
+		return r.handleFailure(ctx, &nr, oldStatus, "TenantLookupFailed", err)
+	}
+	if err != nil {
 		l.Error(err, "tenant not found", "tenant", tenant)
-		return ctrl.Result{}, r.setStatusFailed(ctx, &nr, "TenantNotFound", fmt.Sprintf("tenant %q not found", tenant))
+		msg := fmt.Sprintf("tenant %q not found", tenant)
+		setCondition(&nr, guardiov1alpha1.CondTenantValid, metav1.ConditionFalse, "TenantNotFound", msg)
+		return r.setStatusTenantFailed(ctx, &nr, nil, oldStatus, "TenantNotFound", msg)
+	}
+	if c := meta.FindStatusCondition(t.Status.Conditions, guardiov1alpha1.CondValid); c != nil && c.Status == metav1.ConditionFalse {
+		msg := fmt.Sprintf("tenant %q is invalid: %s", tenant, c.Message)
+		setCondition(&nr, guardiov1alpha1.CondTenantValid, metav1.ConditionFalse, "TenantInvalid", msg)
+		return r.setStatusTenantFailed(ctx, &nr, t, oldStatus, "TenantInvalid", msg)
+	}
+	setCondition(&nr, guardiov1alpha1.CondTenantValid, metav1.ConditionTrue, "TenantFound",
+		fmt.Sprintf("tenant %q exists and is valid", tenant))
+
+	// Tenant 正在删除：交给 Tenant 的 deletionPolicy 处理，这里不再写入
+	if !t.DeletionTimestamp.IsZero() {
+		l.Info("tenant is being deleted, skip baseline", "nsreq", req.Name, "tenant", tenant)
+		return ctrl.Result{}, nil
+	}
+
+	// Tenant 被 suspend：紧急刹车，不对 namespace 做任何写入
+	// 解除 suspend 会改变 Tenant generation，经 Watch 重新入队后自动收敛
+	if t.Spec.Suspend {
+		if nr.Status.Phase == guardiov1alpha1.PhaseSuspended {
+			return ctrl.Result{}, nil
+		}
+		l.Info("tenant suspended, skip baseline", "nsreq", req.Name, "tenant", tenant)
+		return ctrl.Result{}, r.setStatusSuspended(ctx, &nr, oldStatus, tenant)
+	}
+
+	// TTL：到期删除；临近到期告警，requeue 到下一个时间点
+	ttlRequeue, expired, err := r.reconcileExpiry(ctx, &nr, t, oldStatus)
+	if expired || err != nil {
+		return ctrl.Result{}, err
+	}
+
+	// 临时性失败的退避时间未到：等待（spec 变化或手动重试不受限制）
+	if wait := retryBackoff(&nr); wait > 0 {
+		return ctrl.Result{RequeueAfter: wait}, nil
+	}
+
+	// 需要审批的 env（如 prod）：namespace 创建之前必须集齐审批；已经创建过 namespace 的不回头拦截
+	// 审批记录只能由 webhook 按 req.UserInfo 写入 approved-by 注解，这里只负责计数
+	if nr.Status.NamespaceName == "" && guardiov1alpha1.ApprovalRequired(&t.Spec, env) {
+		need := guardiov1alpha1.RequiredApprovals(&t.Spec)
+		if got := guardiov1alpha1.CountApprovals(&t.Spec, &nr); got < need {
+			msg := fmt.Sprintf("waiting for approvals %d/%d from groups %v", got, need, guardiov1alpha1.ApproverGroups(&t.Spec, tenant))
+			setCondition(&nr, guardiov1alpha1.CondApproved, metav1.ConditionFalse, "AwaitingApproval", msg)
+			nr.Status.Phase = guardiov1alpha1.PhaseAwaitingApproval
+			nr.Status.Reason = "AwaitingApproval"
+			nr.Status.Message = msg
+			return ctrl.Result{RequeueAfter: ttlRequeue}, r.updateStatus(ctx, &nr, oldStatus)
+		}
+		setCondition(&nr, guardiov1alpha1.CondApproved, metav1.ConditionTrue, "Approved",
+			fmt.Sprintf("approved by %s", strings.Join(guardiov1alpha1.ApprovedBy(&nr), ", ")))
+	}
+
+	// Tenant 配置了 rollout：按旧 baseline 下发过的 namespace（不论现在是 Provisioned、Suspended 还是 Failed / Retrying）
+	// 只有 Tenant 变化时，等 Tenant controller 按阶段/速率放行；
+	// request 自身 spec 变化（移交 ownerGroup、调整 size / extraBindings）照常下发（顺带用上新的 Tenant baseline）
+	// 放行会更新 Tenant status，经 Watch 重新入队
+	onlyTenantChanged := requestApplied(&nr)
+	if onlyTenantChanged && !rolloutAdmitted(t, &nr) {
+		nr.Status.Reason = "RolloutPending"
+		nr.Status.Message = fmt.Sprintf("waiting for tenant %s to roll out generation %d", tenant, t.Generation)
+		return ctrl.Result{RequeueAfter: ttlRequeue}, r.updateStatus(ctx, &nr, oldStatus)
 	}
 
-	nsName := buildNamespaceName(tenant, env)
+	// 已 Provisioned 且 Tenant 没有变化时，EnsureBaseline 产生的变更就是被修复的漂移
+	// （baseline 对象被改/被删，经 Watch 触发到这里）
+	// （request 自身 spec 变化，如调整 size，同样不算漂移）
+	steady := nr.Status.Phase == guardiov1alpha1.PhaseProvisioned && nr.Status.NamespaceName != "" &&
+		tenantObserved(t, &nr, guardiov1alpha1.RolloutSpecHash(&t.Spec)) && requestApplied(&nr)
+
+	bspec := BaselineSpec{
+		Tenant:            tenant,
+		Env:               env,
+		OwnerGroup:        strings.TrimSpace(nr.Spec.OwnerGroup),
+		Size:              strings.TrimSpace(nr.Spec.Size),
+		ExtraBindings:     nr.Spec.ExtraBindings,
+		RequestName:       nr.Name,
+		Requester:         guardiov1alpha1.RequesterAnnotations(&nr),
+		PreviousManifests: nr.Status.Manifests,
+		TenantObj:         t,
+		Version:           guardiov1alpha1.BaselineVersionOf(&t.Spec),
+	}
+
+	// 接管已有 namespace：先 dry-run 出变更等待确认
+	adopting := nr.Status.NamespaceName == "" && strings.TrimSpace(nr.Spec.Adopt) != ""
+	if adopting {
+		if proceed, err := r.reconcileAdoption(ctx, &nr, bspec, oldStatus); !proceed || err != nil {
+			return ctrl.Result{RequeueAfter: ttlRequeue}, err
+		}
+	}
 
-	// 创建 Namespace（若已存在则继续）
-	if err := r.ensureNamespace(ctx, nsName, &nr); err != nil {
+	// namespace 名称：已 Provisioned 的沿用 status，否则按 Tenant 命名策略计算并校验 namespaceNamePattern
+	// （接管的是已有 namespace，不受命名约束）
+	nsName := nr.Status.NamespaceName
+	if nsName == "" {
+		nsName = guardiov1alpha1.NamespaceNameFor(&t.Spec, &nr)
+		if errs := guardiov1alpha1.ValidateNamespaceName(&t.Spec, nsName, field.NewPath("spec", "namespaceName")); !adopting && len(errs) > 0 {
+			msg := errs.ToAggregate().Error()
+			setCondition(&nr, guardiov1alpha1.CondNamespaceReady, metav1.ConditionFalse, "InvalidNamespaceName", msg)
+			return ctrl.Result{}, r.setStatusFailed(ctx, &nr, oldStatus, "InvalidNamespaceName", msg)
+		}
+	}
+
+	// 创建 Namespace（若已存在且归属本 request/tenant 则继续）
+	if err := r.ensureNamespace(ctx, nsName, &nr, adopting, bspec); err != nil {
 		l.Error(err, "ensure namespace failed", "namespace", nsName)
-		return ctrl.Result{}, r.setStatusFailed(ctx, &nr, "NamespaceCreateFailed", err.Error())
+		reason := "NamespaceCreateFailed"
+		switch {
+		case errors.Is(err, errNamespaceConflict):
+			reason = "NamespaceConflict"
+		case errors.Is(err, errNamespaceTerminating):
+			reason = "NamespaceTerminating"
+		}
+		setCondition(&nr, guardiov1alpha1.CondNamespaceReady, metav1.ConditionFalse, reason, err.Error())
+		return r.handleFailure(ctx, &nr, oldStatus, reason, err)
+	}
+	setCondition(&nr, guardiov1alpha1.CondNamespaceReady, metav1.ConditionTrue, "NamespaceReady",
+		fmt.Sprintf("namespace %q exists", nsName))
+	// namespace 一建好就记到 status：之后 baseline 失败（Failed/Retrying）时，删除 request 或 TTL 到期仍能回收它
+	nr.Status.NamespaceName = nsName
+	if adopting && nr.Status.Adoption != nil {
+		nr.Status.Adoption.Adopted = true
 	}
 
-	// 创建 namespace 成功后，下发 baseline
-	var t guardiov1alpha1.Tenant
-	if err := r.Get(ctx, types.NamespacedName{Name: tenant}, &t); err != nil {
-		l.Error(err, "get tenant failed", "tenant", tenant)
+	// 创建 namespace 成功后，下发 baseline（manifests 的逐个结果直接写进 status）
+	bspec.manifests = &nr.Status.Manifests
+	bspec.conflicts = map[string][]string{}
+	var serviceAccounts []string
+	bspec.serviceAccounts = &serviceAccounts
+	// request 已在这个 namespace 下发过：之前下发的对象 managed/hash 标签被删改时照常修复，不当成外来对象
+	if nr.Status.AppliedGeneration > 0 || nr.Status.ObservedTenantGeneration > 0 {
+		bspec.recorded = recordedObjects(&nr)
+	}
+	// 接管 namespace 后第一次成功下发之前：确认过的 plan 里要覆盖的已有对象允许接管
+	if a := nr.Status.Adoption; a != nil && a.Adopted && nr.Status.AppliedGeneration == 0 {
+		bspec.takeOver = adoptedObjects(a.Plan)
 	}
-	if err := EnsureBaseline(ctx, r.Client, nsName, BaselineSpec{
-		Tenant:      tenant,
-		Env:         env,
-		OwnerGroup:  strings.TrimSpace(nr.Spec.OwnerGroup),
-		RequestName: nr.Name,
-		TenantObj:   &t,
-	}); err != nil {
+	changed, err := EnsureBaseline(ctx, r.Client, nsName, bspec)
+	setBaselineConditions(&nr, err, bspec.conflicts)
+	if werr := r.manifestWatches.watch(nr.Status.Manifests); werr != nil {
+		l.Error(werr, "watch manifest kinds failed", "namespace", nsName)
+	}
+	if err != nil {
 		l.Error(err, "ensure baseline failed", "namespace", nsName)
-		return ctrl.Result{}, r.setStatusFailed(ctx, &nr, "BaselineFailed", err.Error())
+		return r.handleFailure(ctx, &nr, oldStatus, ReasonBaselineFailed, err)
+	}
+
+	// ownerGroup 移交：EnsureBaseline 已经重新绑定 guardian-owner-edit 并更新 owner-group 标签/注解
+	if prev := nr.Status.OwnerGroup; prev != "" && prev != bspec.OwnerGroup {
+		r.recordOwnerTransfer(&nr, prev, bspec.OwnerGroup)
+		l.Info("owner group transferred", "nsreq", req.Name, "from", prev, "to", bspec.OwnerGroup)
+	}
+	nr.Status.OwnerGroup = bspec.OwnerGroup
+	nr.Status.ServiceAccounts = serviceAccounts
+
+	if steady && len(changed) > 0 {
+		nr.Status.DriftCorrected += int32(len(changed))
+		r.Recorder.Eventf(&nr, corev1.EventTypeWarning, "DriftCorrected",
+			"restored baseline objects in namespace %s: %s", nsName, strings.Join(changed, ", "))
+		l.Info("baseline drift corrected", "nsreq", req.Name, "namespace", nsName, "objects", changed)
 	}
 
 	// 回写 status
 	nr.Status.Phase = guardiov1alpha1.PhaseProvisioned
-	nr.Status.NamespaceName = nsName
-	nr.Status.Reason = ""
-	nr.Status.Message = ""
+	nr.Status.Retry = nil
+	nr.Status.ObservedTenantGeneration = t.Generation
+	nr.Status.ObservedTenantSpecHash = guardiov1alpha1.RolloutSpecHash(&t.Spec)
+	nr.Status.AppliedGeneration = nr.Generation
+	nr.Status.Reason = "Provisioned"
+	nr.Status.Message = fmt.Sprintf("namespace %s provisioned with baseline", nsName)
 
-	if err := r.Status().Update(ctx, &nr); err != nil {
+	if err := r.updateStatus(ctx, &nr, oldStatus); err != nil {
 		// 常见冲突：重试即可
 		return ctrl.Result{}, err
 	}
 
-	l.Info("namespace provisioned", "nsreq", req.Name, "namespace", nsName)
-	return ctrl.Result{}, nil
+	if !steady {
+		l.Info("namespace provisioned", "nsreq", req.Name, "namespace", nsName)
+	}
+	return ctrl.Result{RequeueAfter: ttlRequeue}, nil
 }
 
-func (r *NamespaceRequestReconciler) ensureTenantExists(ctx context.Context, tenant string) error {
+// requestApplied：request 自身 spec 的当前 generation 已成功下发过 baseline
+// （升级前没有记录 appliedGeneration 的按 observedGeneration 判断）
+func requestApplied(nr *guardiov1alpha1.NamespaceRequest) bool {
+	if nr.Status.AppliedGeneration > 0 {
+		return nr.Status.AppliedGeneration == nr.Generation
+	}
+	return baselineProvisioned(nr) && nr.Status.ObservedGeneration == nr.Generation
+}
+
+// recordedObjects：status 里记录的、request 下发过的对象（Kind/name）
+func recordedObjects(nr *guardiov1alpha1.NamespaceRequest) map[string]bool {
+	out := map[string]bool{}
+	for _, sa := range nr.Status.ServiceAccounts {
+		out["ServiceAccount/"+sa] = true
+	}
+	for _, m := range nr.Status.Manifests {
+		if m.Applied && m.Kind != "" {
+			out[m.Kind+"/"+m.ObjectName] = true
+		}
+	}
+	return out
+}
+
+func (r *NamespaceRequestReconciler) getTenant(ctx context.Context, tenant string) (*guardiov1alpha1.Tenant, error) {
 	if tenant == "" {
-		return fmt.Errorf("spec.tenant is empty")
+		return nil, fmt.Errorf("spec.tenant is empty")
 	}
 	var t guardiov1alpha1.Tenant
-	return r.Get(ctx, types.NamespacedName{Name: tenant}, &t)
+	if err := r.Get(ctx, types.NamespacedName{Name: tenant}, &t); err != nil {
+		return nil, err
+	}
+	return &t, nil
 }
 
-func (r *NamespaceRequestReconciler) ensureNamespace(ctx context.Context, nsName string, nr *guardiov1alpha1.NamespaceRequest) error {
+// ensureNamespace：adopt=true 时允许认领未受管理的 namespace（已经过 dry-run 确认）
+func (r *NamespaceRequestReconciler) ensureNamespace(ctx context.Context, nsName string, nr *guardiov1alpha1.NamespaceRequest,
+	adopt bool, spec BaselineSpec) error {
 	var ns corev1.Namespace
 	err := r.Get(ctx, types.NamespacedName{Name: nsName}, &ns)
 	if err == nil {
-		// 已存在：确保关键标签存在（阶段1最小幂等）
+		// 正在删除的 namespace 不认领：等它删完再重新创建
+		if ns.DeletionTimestamp != nil {
+			return fmt.Errorf("%w: namespace %q", errNamespaceTerminating, nsName)
+		}
+		if !claimableBy(&ns, nr) && !(adopt && unmanagedNamespace(&ns)) {
+			return fmt.Errorf("%w: namespace %q belongs to tenant=%q request=%q", errNamespaceConflict, nsName,
+				ns.Labels[guardiov1alpha1.LabelTenant], ns.Labels[guardiov1alpha1.LabelRequest])
+		}
+		// retain 留下的 orphaned namespace：先重新认领其中的 baseline 对象，失败时 namespace 保持 orphaned，下次重试
+		if ns.Labels[guardiov1alpha1.LabelOrphaned] == "true" {
+			if err := reclaimOrphanedObjects(ctx, r.Client, nsName, spec); err != nil {
+				return fmt.Errorf("reclaim baseline objects in namespace %q: %w", nsName, err)
+			}
+		}
+		// 已存在：确保关键标签与申请人注解存在（阶段1最小幂等）
+		// orphaned namespace 被重新申请时在这里重新认领，并取消待回收时间
 		desired := desiredNSLabels(ns.Labels, nsName, nr)
-		if !labelsEqual(ns.Labels, desired) {
+		desiredAnn := mergeLabels(ns.Annotations, guardiov1alpha1.RequesterAnnotations(nr))
+		delete(desiredAnn, guardiov1alpha1.AnnReclaimAfter)
+		if !labelsEqual(ns.Labels, desired) || !labelsEqual(ns.Annotations, desiredAnn) {
 			ns.Labels = desired
+			ns.Annotations = desiredAnn
 			return r.Update(ctx, &ns)
 		}
 		return nil
@@ -128,46 +355,54 @@ func (r *NamespaceRequestReconciler) ensureNamespace(ctx context.Context, nsName
 	// 不存在：创建
 	ns = corev1.Namespace{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:   nsName,
-			Labels: desiredNSLabels(nil, nsName, nr),
+			Name:        nsName,
+			Labels:      desiredNSLabels(nil, nsName, nr),
+			Annotations: guardiov1alpha1.RequesterAnnotations(nr),
 		},
 	}
 	return r.Create(ctx, &ns)
 }
 
-func (r *NamespaceRequestReconciler) setStatusFailed(ctx context.Context, nr *guardiov1alpha1.NamespaceRequest, reason, msg string) error {
+func (r *NamespaceRequestReconciler) setStatusFailed(ctx context.Context, nr *guardiov1alpha1.NamespaceRequest,
+	old *guardiov1alpha1.NamespaceRequestStatus, reason, msg string) error {
 	nr.Status.Phase = guardiov1alpha1.PhaseFailed
 	nr.Status.Reason = reason
 	nr.Status.Message = msg
-	// NamespaceName 保留为空
-	return r.Status().Update(ctx, nr)
+	// 终态失败不自动重试：等 spec/Tenant 变化或 guardian.io/retry
+	nr.Status.Retry = nil
+	// NamespaceName 保留：namespace 已创建的，删除 request 时照常回收
+	return r.updateStatus(ctx, nr, old)
 }
 
-// buildNamespaceName: <tenant>-<env>
-// 生产建议加 ownerGroup/team 等，阶段1先最小化
-func buildNamespaceName(tenant, env string) string {
-	raw := fmt.Sprintf("%s-%s", tenant, env)
-	return sanitizeDNS1123(raw)
+func (r *NamespaceRequestReconciler) setStatusSuspended(ctx context.Context, nr *guardiov1alpha1.NamespaceRequest,
+	old *guardiov1alpha1.NamespaceRequestStatus, tenant string) error {
+	nr.Status.Phase = guardiov1alpha1.PhaseSuspended
+	nr.Status.Reason = "TenantSuspended"
+	nr.Status.Message = fmt.Sprintf("tenant %q is suspended, baseline reconcile is paused", tenant)
+	// NamespaceName 保留：解除 suspend 后继续使用同一个 namespace
+	return r.updateStatus(ctx, nr, old)
 }
 
-// DNS1123 label: lowercase alphanum or '-', start/end alphanum, max 63
-var dns1123Re = regexp.MustCompile(`[^a-z0-9-]+`)
+// errNamespaceConflict：目标 namespace 已存在但属于别的 tenant/request，或不受 guardian 管理
+var errNamespaceConflict = errors.New("namespace name collision")
 
-func sanitizeDNS1123(s string) string {
-	s = strings.ToLower(strings.TrimSpace(s))
-	s = dns1123Re.ReplaceAllString(s, "-")
-	s = strings.Trim(s, "-")
-	if s == "" {
-		return "ns"
+// errNamespaceTerminating：目标 namespace 正在删除，按临时性失败退避重试
+var errNamespaceTerminating = errors.New("namespace is terminating")
+
+// claimableBy：本 request 之前创建的、同 tenant 下的 orphaned namespace 可以（重新）认领；
+// 打上 request 标签之前创建的老 namespace 按 tenant 匹配
+func claimableBy(ns *corev1.Namespace, nr *guardiov1alpha1.NamespaceRequest) bool {
+	if ns.Labels[guardiov1alpha1.LabelTenant] != nr.Spec.Tenant {
+		return false
 	}
-	if len(s) > 63 {
-		s = s[:63]
-		s = strings.Trim(s, "-")
-		if s == "" {
-			return "ns"
-		}
+	if ns.Labels[guardiov1alpha1.LabelOrphaned] == "true" {
+		return true
 	}
-	return s
+	if ns.Labels[guardiov1alpha1.LabelManaged] != "true" {
+		return false
+	}
+	owner := ns.Labels[guardiov1alpha1.LabelRequest]
+	return owner == "" || owner == nr.Name
 }
 
 func desiredNSLabels(existing map[string]string, nsName string, nr *guardiov1alpha1.NamespaceRequest) map[string]string {
@@ -183,7 +418,8 @@ func desiredNSLabels(existing map[string]string, nsName string, nr *guardiov1alp
 	ownerGroup := strings.TrimSpace(nr.Spec.OwnerGroup)
 	out["guardian.io/owner-group"] = guardiov1alpha1.ShortHash16(ownerGroup)
 	out["guardian.io/managed"] = "true"
-	out["guardian.io/request"] = nr.Name
+	out[guardiov1alpha1.LabelRequest] = nr.Name
+	delete(out, guardiov1alpha1.LabelOrphaned)
 	return out
 }
 
@@ -199,8 +435,65 @@ func labelsEqual(a, b map[string]string) bool {
 	return true
 }
 
+// requestsForTenant：Tenant spec 变化时，把该 tenant 下所有 NamespaceRequest 重新入队
+func (r *NamespaceRequestReconciler) requestsForTenant(ctx context.Context, obj client.Object) []reconcile.Request {
+	var list guardiov1alpha1.NamespaceRequestList
+	if err := r.List(ctx, &list, client.MatchingLabels{guardiov1alpha1.LabelTenant: obj.GetName()}); err != nil {
+		log.FromContext(ctx).Error(err, "list namespacerequests for tenant failed", "tenant", obj.GetName())
+		return nil
+	}
+	out := make([]reconcile.Request, 0, len(list.Items))
+	for i := range list.Items {
+		out = append(out, reconcile.Request{NamespacedName: types.NamespacedName{Name: list.Items[i].Name}})
+	}
+	return out
+}
+
+// requestForBaselineObject：baseline 对象通过 request-raw 注解映射回 NamespaceRequest
+// （request-hash 标签用于校验，防止注解被随意改写后指向别的 request）
+func requestForBaselineObject(_ context.Context, obj client.Object) []reconcile.Request {
+	name := obj.GetAnnotations()[guardiov1alpha1.AnnRequestRaw]
+	if name == "" || obj.GetLabels()[guardiov1alpha1.LabelRequestHash] != guardiov1alpha1.ShortHash16(name) {
+		return nil
+	}
+	return []reconcile.Request{{NamespacedName: types.NamespacedName{Name: name}}}
+}
+
+// baselineObjectPredicate：只关心 guardian 管理的对象；update 时新旧任一带 managed 标签都算（标签被删也是漂移）
+var baselineObjectPredicate = predicate.Funcs{
+	CreateFunc:  func(e event.CreateEvent) bool { return isManaged(e.Object) },
+	DeleteFunc:  func(e event.DeleteEvent) bool { return isManaged(e.Object) },
+	UpdateFunc:  func(e event.UpdateEvent) bool { return isManaged(e.ObjectOld) || isManaged(e.ObjectNew) },
+	GenericFunc: func(e event.GenericEvent) bool { return isManaged(e.Object) },
+}
+
+func isManaged(obj client.Object) bool {
+	return obj != nil && obj.GetLabels()[guardiov1alpha1.LabelManaged] == "true"
+}
+
 func (r *NamespaceRequestReconciler) SetupWithManager(mgr ctrl.Manager) error {
-	return ctrl.NewControllerManagedBy(mgr).
+	mapBaseline := handler.EnqueueRequestsFromMapFunc(requestForBaselineObject)
+	mapPropagate := handler.EnqueueRequestsFromMapFunc(r.requestsForPropagationSource)
+	c, err := ctrl.NewControllerManagedBy(mgr).
 		For(&guardiov1alpha1.NamespaceRequest{}).
-		Complete(r)
+		// 只关心 spec 变化（generation）与 rollout 放行，其余 Tenant status 更新不触发 fan-out
+		Watches(&guardiov1alpha1.Tenant{},
+			handler.EnqueueRequestsFromMapFunc(r.requestsForTenant),
+			builder.WithPredicates(predicate.Or(predicate.GenerationChangedPredicate{}, rolloutAdmissionChanged))).
+		// baseline 对象被修改/删除时重新下发（漂移修复）
+		Watches(&rbacv1.RoleBinding{}, mapBaseline, builder.WithPredicates(baselineObjectPredicate)).
+		Watches(&corev1.ServiceAccount{}, mapBaseline, builder.WithPredicates(baselineObjectPredicate)).
+		Watches(&corev1.ResourceQuota{}, mapBaseline, builder.WithPredicates(baselineObjectPredicate)).
+		Watches(&corev1.LimitRange{}, mapBaseline, builder.WithPredicates(baselineObjectPredicate)).
+		Watches(&networkingv1.NetworkPolicy{}, mapBaseline, builder.WithPredicates(baselineObjectPredicate)).
+		// Secret/ConfigMap：复制品漂移修复 + 来源变化时同步到各 namespace（只看带 guardian.io/propagation 标签的）
+		Watches(&corev1.Secret{}, mapPropagate, builder.WithPredicates(propagationObjectPredicate)).
+		Watches(&corev1.ConfigMap{}, mapPropagate, builder.WithPredicates(propagationObjectPredicate)).
+		Build(r)
+	if err != nil {
+		return err
+	}
+	// Tenant manifests 的对象：kind 不固定，下发后按需 Watch
+	r.manifestWatches = &manifestWatcher{controller: c, cache: mgr.GetCache(), watched: map[schema.GroupVersionKind]bool{}}
+	return nil
 }
@@ -18,14 +18,26 @@ package controller
 
 import (
 	"context"
+	"fmt"
+	"slices"
+	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 
 	guardianv1alpha1 "github.com/CATDOGME/namespace-guardian/api/v1alpha1"
 )
@@ -33,52 +45,1823 @@ import (
 var _ = Describe("NamespaceRequest Controller", func() {
 	Context("When reconciling a resource", func() {
 		const resourceName = "test-resource"
+		const tenantName = "nr-test-tenant"
 
 		ctx := context.Background()
 
 		typeNamespacedName := types.NamespacedName{
-			Name:      resourceName,
-			Namespace: "default", // TODO(user):Modify as needed
+			Name: resourceName,
+		}
+		tenantNamespacedName := types.NamespacedName{
+			Name: tenantName,
 		}
 		namespacerequest := &guardianv1alpha1.NamespaceRequest{}
 
 		BeforeEach(func() {
+			By("creating the Tenant referenced by the request")
+			t := &guardianv1alpha1.Tenant{}
+			err := k8sClient.Get(ctx, tenantNamespacedName, t)
+			if err != nil && errors.IsNotFound(err) {
+				t = &guardianv1alpha1.Tenant{
+					ObjectMeta: metav1.ObjectMeta{Name: tenantName},
+					Spec: guardianv1alpha1.TenantSpec{
+						AllowedGroups: []string{tenantName + ":dev"},
+					},
+				}
+				Expect(k8sClient.Create(ctx, t)).To(Succeed())
+			}
+
 			By("creating the custom resource for the Kind NamespaceRequest")
-			err := k8sClient.Get(ctx, typeNamespacedName, namespacerequest)
+			err = k8sClient.Get(ctx, typeNamespacedName, namespacerequest)
 			if err != nil && errors.IsNotFound(err) {
 				resource := &guardianv1alpha1.NamespaceRequest{
 					ObjectMeta: metav1.ObjectMeta{
-						Name:      resourceName,
-						Namespace: "default",
+						Name: resourceName,
+						Labels: map[string]string{
+							guardianv1alpha1.LabelTenant: tenantName,
+						},
+					},
+					Spec: guardianv1alpha1.NamespaceRequestSpec{
+						Tenant:     tenantName,
+						Env:        "dev",
+						OwnerGroup: tenantName + ":dev",
 					},
-					// TODO(user): Specify other spec details if needed.
 				}
 				Expect(k8sClient.Create(ctx, resource)).To(Succeed())
 			}
 		})
 
 		AfterEach(func() {
-			// TODO(user): Cleanup logic after each test, like removing the resource instance.
 			resource := &guardianv1alpha1.NamespaceRequest{}
 			err := k8sClient.Get(ctx, typeNamespacedName, resource)
+			if errors.IsNotFound(err) {
+				return
+			}
 			Expect(err).NotTo(HaveOccurred())
 
 			By("Cleanup the specific resource instance NamespaceRequest")
 			Expect(k8sClient.Delete(ctx, resource)).To(Succeed())
+
+			By("Reconciling once more so the finalizer is released")
+			controllerReconciler := &NamespaceRequestReconciler{
+				Client:   k8sClient,
+				Scheme:   k8sClient.Scheme(),
+				Recorder: record.NewFakeRecorder(100),
+			}
+			_, err = controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
 		})
 		It("should successfully reconcile the resource", func() {
 			By("Reconciling the created resource")
 			controllerReconciler := &NamespaceRequestReconciler{
-				Client: k8sClient,
-				Scheme: k8sClient.Scheme(),
+				Client:   k8sClient,
+				Scheme:   k8sClient.Scheme(),
+				Recorder: record.NewFakeRecorder(100),
 			}
 
 			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{
 				NamespacedName: typeNamespacedName,
 			})
 			Expect(err).NotTo(HaveOccurred())
-			// TODO(user): Add more specific assertions depending on your controller's reconciliation logic.
-			// Example: If you expect a certain status condition after reconciliation, verify it here.
+
+			Expect(k8sClient.Get(ctx, typeNamespacedName, namespacerequest)).To(Succeed())
+			Expect(namespacerequest.Status.Phase).To(Equal(guardianv1alpha1.PhaseProvisioned))
+			Expect(namespacerequest.Status.NamespaceName).NotTo(BeEmpty())
+		})
+
+		It("should report conditions, observedGeneration and events", func() {
+			recorder := record.NewFakeRecorder(100)
+			controllerReconciler := &NamespaceRequestReconciler{
+				Client:   k8sClient,
+				Scheme:   k8sClient.Scheme(),
+				Recorder: recorder,
+			}
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(k8sClient.Get(ctx, typeNamespacedName, namespacerequest)).To(Succeed())
+			Expect(namespacerequest.Status.ObservedGeneration).To(Equal(namespacerequest.Generation))
+			for _, condType := range []string{
+				guardianv1alpha1.CondTenantValid,
+				guardianv1alpha1.CondNamespaceReady,
+				guardianv1alpha1.CondRBACReady,
+				guardianv1alpha1.CondServiceAccountsReady,
+				guardianv1alpha1.CondPropagationReady,
+				guardianv1alpha1.CondQuotaReady,
+				guardianv1alpha1.CondLimitRangeReady,
+				guardianv1alpha1.CondNetworkPolicyReady,
+				guardianv1alpha1.CondManifestsReady,
+				guardianv1alpha1.CondBaselineVersionReady,
+			} {
+				Expect(meta.IsStatusConditionTrue(namespacerequest.Status.Conditions, condType)).To(BeTrue(), condType)
+			}
+			Expect(recorder.Events).To(Receive(ContainSubstring("Provisioned")))
+
+			By("an unchanged second pass records no new events")
+			for len(recorder.Events) > 0 {
+				<-recorder.Events
+			}
+			_, err = controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(recorder.Events).To(BeEmpty())
+		})
+
+		It("should re-apply the baseline when the Tenant spec changes", func() {
+			controllerReconciler := &NamespaceRequestReconciler{
+				Client:   k8sClient,
+				Scheme:   k8sClient.Scheme(),
+				Recorder: record.NewFakeRecorder(100),
+			}
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+
+			By("raising the tenant quota")
+			t := &guardianv1alpha1.Tenant{}
+			Expect(k8sClient.Get(ctx, tenantNamespacedName, t)).To(Succeed())
+			t.Spec.Baseline = &guardianv1alpha1.TenantBaselineSpec{
+				Quota: &guardianv1alpha1.TenantQuotaSpec{
+					Default: guardianv1alpha1.QuotaHard{Pods: "42"},
+				},
+			}
+			Expect(k8sClient.Update(ctx, t)).To(Succeed())
+
+			_, err = controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(k8sClient.Get(ctx, typeNamespacedName, namespacerequest)).To(Succeed())
+			Expect(namespacerequest.Status.ObservedTenantGeneration).To(Equal(t.Generation))
+
+			rq := &corev1.ResourceQuota{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{
+				Namespace: namespacerequest.Status.NamespaceName,
+				Name:      "guardian-rq-default",
+			}, rq)).To(Succeed())
+			Expect(rq.Spec.Hard.Pods().String()).To(Equal("42"))
+		})
+
+		It("should resize the ResourceQuota when spec.size changes", func() {
+			t := &guardianv1alpha1.Tenant{}
+			Expect(k8sClient.Get(ctx, tenantNamespacedName, t)).To(Succeed())
+			previous := t.Spec.Baseline.DeepCopy()
+			t.Spec.Baseline = &guardianv1alpha1.TenantBaselineSpec{
+				Quota: &guardianv1alpha1.TenantQuotaSpec{
+					Default: guardianv1alpha1.QuotaHard{Pods: "10", Services: "5"},
+					Sizes: map[string]map[string]guardianv1alpha1.QuotaHard{
+						"dev": {
+							"small": {Pods: "20"},
+							"large": {Pods: "80"},
+						},
+					},
+				},
+			}
+			Expect(k8sClient.Update(ctx, t)).To(Succeed())
+			DeferCleanup(func() {
+				Expect(k8sClient.Get(ctx, tenantNamespacedName, t)).To(Succeed())
+				t.Spec.Baseline = previous
+				Expect(k8sClient.Update(ctx, t)).To(Succeed())
+			})
+
+			Expect(k8sClient.Get(ctx, typeNamespacedName, namespacerequest)).To(Succeed())
+			namespacerequest.Spec.Size = "small"
+			Expect(k8sClient.Update(ctx, namespacerequest)).To(Succeed())
+
+			recorder := record.NewFakeRecorder(100)
+			controllerReconciler := &NamespaceRequestReconciler{
+				Client:   k8sClient,
+				Scheme:   k8sClient.Scheme(),
+				Recorder: recorder,
+			}
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(k8sClient.Get(ctx, typeNamespacedName, namespacerequest)).To(Succeed())
+			nsName := namespacerequest.Status.NamespaceName
+			rqKey := types.NamespacedName{Namespace: nsName, Name: "guardian-rq-default"}
+
+			rq := &corev1.ResourceQuota{}
+			Expect(k8sClient.Get(ctx, rqKey, rq)).To(Succeed())
+			Expect(rq.Spec.Hard.Pods().String()).To(Equal("20"))
+			services := rq.Spec.Hard[corev1.ResourceServices]
+			Expect(services.String()).To(Equal("5"))
+
+			By("switching to a larger size")
+			namespacerequest.Spec.Size = "large"
+			Expect(k8sClient.Update(ctx, namespacerequest)).To(Succeed())
+			_, err = controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(k8sClient.Get(ctx, rqKey, rq)).To(Succeed())
+			Expect(rq.Spec.Hard.Pods().String()).To(Equal("80"))
+			Expect(k8sClient.Get(ctx, typeNamespacedName, namespacerequest)).To(Succeed())
+			Expect(namespacerequest.Status.NamespaceName).To(Equal(nsName))
+			Expect(namespacerequest.Status.DriftCorrected).To(BeZero())
+		})
+
+		It("should publish an adoption plan and adopt only after confirmation", func() {
+			legacy := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "legacy-adopt"}}
+			Expect(k8sClient.Create(ctx, legacy)).To(Succeed())
+			handMade := &networkingv1.NetworkPolicy{
+				ObjectMeta: metav1.ObjectMeta{Name: "guardian-np-default-deny", Namespace: legacy.Name},
+				Spec:       networkingv1.NetworkPolicySpec{PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeIngress}},
+			}
+			Expect(k8sClient.Create(ctx, handMade)).To(Succeed())
+
+			adoptKey := types.NamespacedName{Name: resourceName + "-adopt"}
+			adopt := &guardianv1alpha1.NamespaceRequest{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:   adoptKey.Name,
+					Labels: map[string]string{guardianv1alpha1.LabelTenant: tenantName},
+				},
+				Spec: guardianv1alpha1.NamespaceRequestSpec{
+					Tenant:     tenantName,
+					Env:        "dev",
+					OwnerGroup: tenantName + ":dev",
+					Adopt:      legacy.Name,
+				},
+			}
+			Expect(k8sClient.Create(ctx, adopt)).To(Succeed())
+
+			controllerReconciler := &NamespaceRequestReconciler{
+				Client:   k8sClient,
+				Scheme:   k8sClient.Scheme(),
+				Recorder: record.NewFakeRecorder(100),
+			}
+			DeferCleanup(func() {
+				Expect(k8sClient.Delete(ctx, adopt)).To(Succeed())
+				_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: adoptKey})
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: adoptKey})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(k8sClient.Get(ctx, adoptKey, adopt)).To(Succeed())
+			Expect(adopt.Status.Phase).To(Equal(guardianv1alpha1.PhaseAdoptionPending))
+			Expect(adopt.Status.Adoption).NotTo(BeNil())
+			Expect(adopt.Status.Adoption.PlanHash).NotTo(BeEmpty())
+			Expect(adopt.Status.Adoption.Plan).To(ContainElement(guardianv1alpha1.BaselineObjectChange{
+				Kind: "ResourceQuota", Name: "guardian-rq-default", Action: guardianv1alpha1.BaselineActionCreate,
+			}))
+			Expect(adopt.Status.Adoption.Plan).To(ContainElement(guardianv1alpha1.BaselineObjectChange{
+				Kind: "NetworkPolicy", Name: handMade.Name, Action: guardianv1alpha1.BaselineActionOverwrite,
+			}))
+
+			By("checking the dry-run did not touch the namespace")
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: legacy.Name}, legacy)).To(Succeed())
+			Expect(legacy.Labels).NotTo(HaveKey(guardianv1alpha1.LabelManaged))
+			rq := &corev1.ResourceQuota{}
+			err = k8sClient.Get(ctx, types.NamespacedName{Namespace: legacy.Name, Name: "guardian-rq-default"}, rq)
+			Expect(errors.IsNotFound(err)).To(BeTrue())
+
+			By("confirming the plan")
+			adopt.Annotations = map[string]string{guardianv1alpha1.AnnAdoptConfirm: adopt.Status.Adoption.PlanHash}
+			Expect(k8sClient.Update(ctx, adopt)).To(Succeed())
+			_, err = controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: adoptKey})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(k8sClient.Get(ctx, adoptKey, adopt)).To(Succeed())
+			Expect(adopt.Status.Phase).To(Equal(guardianv1alpha1.PhaseProvisioned))
+			Expect(adopt.Status.NamespaceName).To(Equal(legacy.Name))
+			Expect(adopt.Status.Adoption.Adopted).To(BeTrue())
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: legacy.Name}, legacy)).To(Succeed())
+			Expect(legacy.Labels).To(HaveKeyWithValue(guardianv1alpha1.LabelManaged, "true"))
+
+			By("taking over the hand-made policy listed in the confirmed plan")
+			Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(handMade), handMade)).To(Succeed())
+			Expect(handMade.Labels).To(HaveKeyWithValue(guardianv1alpha1.LabelManaged, "true"))
+			Expect(handMade.Annotations).To(HaveKeyWithValue(guardianv1alpha1.AnnTakenOver, "true"))
+		})
+
+		It("should rebind the owner and record history when the owner group is transferred", func() {
+			controllerReconciler := &NamespaceRequestReconciler{
+				Client:   k8sClient,
+				Scheme:   k8sClient.Scheme(),
+				Recorder: record.NewFakeRecorder(100),
+			}
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(k8sClient.Get(ctx, typeNamespacedName, namespacerequest)).To(Succeed())
+			Expect(namespacerequest.Status.OwnerGroup).To(Equal(tenantName + ":dev"))
+
+			By("accepting a transfer to another group (as the webhook would write it)")
+			newOwner := tenantName + ":team-b"
+			namespacerequest.Spec.OwnerGroup = newOwner
+			namespacerequest.Annotations = map[string]string{
+				guardianv1alpha1.AnnTransferRequestedBy: "alice",
+				guardianv1alpha1.AnnTransferAcceptedBy:  "bob",
+			}
+			Expect(k8sClient.Update(ctx, namespacerequest)).To(Succeed())
+			_, err = controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(k8sClient.Get(ctx, typeNamespacedName, namespacerequest)).To(Succeed())
+			Expect(namespacerequest.Status.OwnerGroup).To(Equal(newOwner))
+			Expect(namespacerequest.Status.OwnerHistory).To(HaveLen(1))
+			Expect(namespacerequest.Status.OwnerHistory[0].From).To(Equal(tenantName + ":dev"))
+			Expect(namespacerequest.Status.OwnerHistory[0].AcceptedBy).To(Equal("bob"))
+			Expect(namespacerequest.Status.DriftCorrected).To(BeZero())
+
+			rb := &rbacv1.RoleBinding{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{
+				Namespace: namespacerequest.Status.NamespaceName,
+				Name:      "guardian-owner-edit",
+			}, rb)).To(Succeed())
+			Expect(rb.Subjects).To(HaveLen(1))
+			Expect(rb.Subjects[0].Name).To(Equal(newOwner))
+			Expect(rb.Labels).To(HaveKeyWithValue(guardianv1alpha1.LabelOwnerGroupHash, guardianv1alpha1.ShortHash16(newOwner)))
+			Expect(rb.Annotations).To(HaveKeyWithValue(guardianv1alpha1.AnnOwnerGroupRaw, newOwner))
+		})
+
+		It("should propagate the requester identity to the namespace and baseline objects", func() {
+			requester := map[string]string{
+				guardianv1alpha1.AnnRequestedBy:       "alice",
+				guardianv1alpha1.AnnRequestedByGroups: tenantName + ":dev",
+				guardianv1alpha1.AnnRequestUID:        "0b7e-uid",
+				guardianv1alpha1.AnnRequestedAt:       "2026-01-02T03:04:05Z",
+			}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, namespacerequest)).To(Succeed())
+			namespacerequest.Annotations = requester
+			Expect(k8sClient.Update(ctx, namespacerequest)).To(Succeed())
+
+			controllerReconciler := &NamespaceRequestReconciler{
+				Client:   k8sClient,
+				Scheme:   k8sClient.Scheme(),
+				Recorder: record.NewFakeRecorder(100),
+			}
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(k8sClient.Get(ctx, typeNamespacedName, namespacerequest)).To(Succeed())
+			nsName := namespacerequest.Status.NamespaceName
+
+			ns := &corev1.Namespace{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: nsName}, ns)).To(Succeed())
+			rb := &rbacv1.RoleBinding{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Namespace: nsName, Name: "guardian-owner-edit"}, rb)).To(Succeed())
+			rq := &corev1.ResourceQuota{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Namespace: nsName, Name: "guardian-rq-default"}, rq)).To(Succeed())
+			for k, v := range requester {
+				Expect(ns.Annotations).To(HaveKeyWithValue(k, v))
+				Expect(rb.Annotations).To(HaveKeyWithValue(k, v))
+				Expect(rq.Annotations).To(HaveKeyWithValue(k, v))
+			}
+		})
+
+		It("should reconcile and prune extra rolebindings", func() {
+			Expect(k8sClient.Get(ctx, typeNamespacedName, namespacerequest)).To(Succeed())
+			viewers := guardianv1alpha1.ExtraBinding{Kind: guardianv1alpha1.ExtraBindingKindGroup, Name: tenantName + ":qa", Role: "view"}
+			ci := guardianv1alpha1.ExtraBinding{Kind: guardianv1alpha1.ExtraBindingKindServiceAccount, Name: "ci-deployer", Role: "edit"}
+			namespacerequest.Spec.ExtraBindings = []guardianv1alpha1.ExtraBinding{viewers, ci}
+			Expect(k8sClient.Update(ctx, namespacerequest)).To(Succeed())
+
+			controllerReconciler := &NamespaceRequestReconciler{
+				Client:   k8sClient,
+				Scheme:   k8sClient.Scheme(),
+				Recorder: record.NewFakeRecorder(100),
+			}
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(k8sClient.Get(ctx, typeNamespacedName, namespacerequest)).To(Succeed())
+			nsName := namespacerequest.Status.NamespaceName
+
+			rb := &rbacv1.RoleBinding{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Namespace: nsName, Name: guardianv1alpha1.ExtraBindingName(viewers)}, rb)).To(Succeed())
+			Expect(rb.RoleRef.Name).To(Equal("view"))
+			Expect(rb.Subjects).To(ConsistOf(rbacv1.Subject{Kind: rbacv1.GroupKind, APIGroup: rbacv1.GroupName, Name: tenantName + ":qa"}))
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Namespace: nsName, Name: guardianv1alpha1.ExtraBindingName(ci)}, rb)).To(Succeed())
+			Expect(rb.Subjects).To(ConsistOf(rbacv1.Subject{Kind: rbacv1.ServiceAccountKind, Name: "ci-deployer", Namespace: nsName}))
+
+			By("dropping the viewers binding from the spec")
+			namespacerequest.Spec.ExtraBindings = []guardianv1alpha1.ExtraBinding{ci}
+			Expect(k8sClient.Update(ctx, namespacerequest)).To(Succeed())
+			_, err = controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+			err = k8sClient.Get(ctx, types.NamespacedName{Namespace: nsName, Name: guardianv1alpha1.ExtraBindingName(viewers)}, rb)
+			Expect(errors.IsNotFound(err)).To(BeTrue())
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Namespace: nsName, Name: guardianv1alpha1.ExtraBindingName(ci)}, rb)).To(Succeed())
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Namespace: nsName, Name: "guardian-owner-edit"}, rb)).To(Succeed())
+		})
+
+		It("should not replace the roleRef of unowned or protected rolebindings", func() {
+			controllerReconciler := &NamespaceRequestReconciler{
+				Client:   k8sClient,
+				Scheme:   k8sClient.Scheme(),
+				Recorder: record.NewFakeRecorder(100),
+			}
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(k8sClient.Get(ctx, typeNamespacedName, namespacerequest)).To(Succeed())
+			key := types.NamespacedName{Namespace: namespacerequest.Status.NamespaceName, Name: "guardian-owner-edit"}
+			rb := &rbacv1.RoleBinding{}
+
+			By("protecting the owner binding and changing the owner ClusterRole")
+			Expect(k8sClient.Get(ctx, key, rb)).To(Succeed())
+			previousRole := rb.RoleRef.Name
+			metav1.SetMetaDataAnnotation(&rb.ObjectMeta, guardianv1alpha1.AnnProtect, "true")
+			Expect(k8sClient.Update(ctx, rb)).To(Succeed())
+			DeferCleanup(func() {
+				// 删掉留下的 binding（带 protect 注解的或外来的），下一次 reconcile 会重建
+				Expect(k8sClient.Get(ctx, key, rb)).To(Succeed())
+				Expect(k8sClient.Delete(ctx, rb)).To(Succeed())
+			})
+			t := &guardianv1alpha1.Tenant{}
+			Expect(k8sClient.Get(ctx, tenantNamespacedName, t)).To(Succeed())
+			t.Spec.Baseline = &guardianv1alpha1.TenantBaselineSpec{
+				RBAC: &guardianv1alpha1.TenantRBACSpec{OwnerClusterRole: "view", RoleCatalog: []string{"view"}},
+			}
+			Expect(k8sClient.Update(ctx, t)).To(Succeed())
+			DeferCleanup(func() {
+				Expect(k8sClient.Get(ctx, tenantNamespacedName, t)).To(Succeed())
+				t.Spec.Baseline = nil
+				Expect(k8sClient.Update(ctx, t)).To(Succeed())
+			})
+			_, err = controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(k8sClient.Get(ctx, typeNamespacedName, namespacerequest)).To(Succeed())
+			Expect(namespacerequest.Status.Phase).To(Equal(guardianv1alpha1.PhaseFailed))
+			Expect(namespacerequest.Status.Message).To(ContainSubstring("protected"))
+			Expect(k8sClient.Get(ctx, key, rb)).To(Succeed())
+			Expect(rb.RoleRef.Name).To(Equal(previousRole))
+
+			By("replacing the owner binding with one the request does not manage")
+			Expect(k8sClient.Delete(ctx, rb)).To(Succeed())
+			foreign := &rbacv1.RoleBinding{
+				ObjectMeta: metav1.ObjectMeta{Name: key.Name, Namespace: key.Namespace},
+				RoleRef:    rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "ClusterRole", Name: previousRole},
+				Subjects:   []rbacv1.Subject{{Kind: rbacv1.GroupKind, APIGroup: rbacv1.GroupName, Name: "someone-else"}},
+			}
+			Expect(k8sClient.Create(ctx, foreign)).To(Succeed())
+			metav1.SetMetaDataAnnotation(&namespacerequest.ObjectMeta, guardianv1alpha1.AnnRetry, "now")
+			Expect(k8sClient.Update(ctx, namespacerequest)).To(Succeed())
+			_, err = controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(k8sClient.Get(ctx, typeNamespacedName, namespacerequest)).To(Succeed())
+			Expect(namespacerequest.Status.Phase).To(Equal(guardianv1alpha1.PhaseFailed))
+			Expect(namespacerequest.Status.Message).To(ContainSubstring("not managed by this request"))
+			Expect(k8sClient.Get(ctx, key, rb)).To(Succeed())
+			Expect(rb.RoleRef.Name).To(Equal(previousRole))
+			Expect(rb.Subjects).To(ConsistOf(foreign.Subjects))
+
+			By("refusing an unmanaged binding even when its roleRef already matches")
+			Expect(k8sClient.Delete(ctx, rb)).To(Succeed())
+			foreign = &rbacv1.RoleBinding{
+				ObjectMeta: metav1.ObjectMeta{Name: key.Name, Namespace: key.Namespace},
+				RoleRef:    rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "ClusterRole", Name: "view"},
+				Subjects:   []rbacv1.Subject{{Kind: rbacv1.GroupKind, APIGroup: rbacv1.GroupName, Name: "someone-else"}},
+			}
+			Expect(k8sClient.Create(ctx, foreign)).To(Succeed())
+			metav1.SetMetaDataAnnotation(&namespacerequest.ObjectMeta, guardianv1alpha1.AnnRetry, "again")
+			Expect(k8sClient.Update(ctx, namespacerequest)).To(Succeed())
+			_, err = controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(k8sClient.Get(ctx, typeNamespacedName, namespacerequest)).To(Succeed())
+			Expect(namespacerequest.Status.Phase).To(Equal(guardianv1alpha1.PhaseFailed))
+			Expect(namespacerequest.Status.Message).To(ContainSubstring("not managed by this request"))
+			Expect(k8sClient.Get(ctx, key, rb)).To(Succeed())
+			Expect(rb.Subjects).To(ConsistOf(foreign.Subjects))
+			Expect(rb.Labels).NotTo(HaveKey(guardianv1alpha1.LabelManaged))
+		})
+
+		It("should create the Tenant baseline ServiceAccounts and list them in status", func() {
+			t := &guardianv1alpha1.Tenant{}
+			Expect(k8sClient.Get(ctx, tenantNamespacedName, t)).To(Succeed())
+			t.Spec.Baseline = &guardianv1alpha1.TenantBaselineSpec{
+				RBAC: &guardianv1alpha1.TenantRBACSpec{RoleCatalog: []string{"edit", "admin"}},
+				ServiceAccounts: &guardianv1alpha1.TenantServiceAccountSpec{
+					Default: []guardianv1alpha1.BaselineServiceAccount{
+						{Name: "deployer", ClusterRole: "edit", ImagePullSecrets: []string{"regcred"}},
+					},
+					ByEnv: map[string][]guardianv1alpha1.BaselineServiceAccount{
+						"dev": {{Name: "deployer", ClusterRole: "admin"}, {Name: "reader"}},
+					},
+				},
+			}
+			Expect(k8sClient.Update(ctx, t)).To(Succeed())
+			DeferCleanup(func() {
+				Expect(k8sClient.Get(ctx, tenantNamespacedName, t)).To(Succeed())
+				t.Spec.Baseline = nil
+				Expect(k8sClient.Update(ctx, t)).To(Succeed())
+			})
+
+			controllerReconciler := &NamespaceRequestReconciler{
+				Client:   k8sClient,
+				Scheme:   k8sClient.Scheme(),
+				Recorder: record.NewFakeRecorder(100),
+			}
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(k8sClient.Get(ctx, typeNamespacedName, namespacerequest)).To(Succeed())
+			Expect(namespacerequest.Status.ServiceAccounts).To(Equal([]string{"deployer", "reader"}))
+			nsName := namespacerequest.Status.NamespaceName
+
+			sa := &corev1.ServiceAccount{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Namespace: nsName, Name: "deployer"}, sa)).To(Succeed())
+			Expect(sa.ImagePullSecrets).To(BeEmpty())
+			Expect(sa.Labels).To(HaveKeyWithValue(guardianv1alpha1.LabelManaged, "true"))
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Namespace: nsName, Name: "reader"}, sa)).To(Succeed())
+
+			rb := &rbacv1.RoleBinding{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Namespace: nsName, Name: "guardian-sa-deployer"}, rb)).To(Succeed())
+			Expect(rb.RoleRef.Name).To(Equal("admin"))
+			Expect(rb.Subjects).To(ConsistOf(rbacv1.Subject{Kind: rbacv1.ServiceAccountKind, Name: "deployer", Namespace: nsName}))
+			err = k8sClient.Get(ctx, types.NamespacedName{Namespace: nsName, Name: "guardian-sa-reader"}, rb)
+			Expect(errors.IsNotFound(err)).To(BeTrue())
+
+			By("refusing to take over a ServiceAccount the tenant created")
+			own := &corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: "builder", Namespace: nsName}}
+			Expect(k8sClient.Create(ctx, own)).To(Succeed())
+			Expect(k8sClient.Get(ctx, tenantNamespacedName, t)).To(Succeed())
+			t.Spec.Baseline.ServiceAccounts.Default = append(t.Spec.Baseline.ServiceAccounts.Default,
+				guardianv1alpha1.BaselineServiceAccount{Name: "builder"})
+			Expect(k8sClient.Update(ctx, t)).To(Succeed())
+			_, err = controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(k8sClient.Get(ctx, typeNamespacedName, namespacerequest)).To(Succeed())
+			Expect(namespacerequest.Status.Phase).To(Equal(guardianv1alpha1.PhaseFailed))
+			Expect(namespacerequest.Status.Message).To(ContainSubstring("is not managed by this request"))
+			Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(own), own)).To(Succeed())
+			Expect(own.Labels).NotTo(HaveKey(guardianv1alpha1.LabelManaged))
+		})
+
+		It("should copy, sync and prune propagated Secrets and ConfigMaps", func() {
+			By("creating the source objects in a platform namespace")
+			src := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "nr-test-platform"}}
+			if err := k8sClient.Create(ctx, src); err != nil {
+				Expect(errors.IsAlreadyExists(err)).To(BeTrue())
+			}
+			sourceLabels := map[string]string{guardianv1alpha1.LabelPropagation: guardianv1alpha1.PropagationSource}
+			secret := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "regcred", Namespace: src.Name, Labels: sourceLabels},
+				Type:       corev1.SecretTypeOpaque,
+				Data:       map[string][]byte{"token": []byte("v1")},
+			}
+			Expect(k8sClient.Create(ctx, secret)).To(Succeed())
+			cm := &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: "ca-bundle", Namespace: src.Name, Labels: sourceLabels},
+				Data:       map[string]string{"ca.crt": "pem"},
+			}
+			Expect(k8sClient.Create(ctx, cm)).To(Succeed())
+			DeferCleanup(func() {
+				Expect(k8sClient.Delete(ctx, secret)).To(Succeed())
+				Expect(k8sClient.Delete(ctx, cm)).To(Succeed())
+			})
+
+			t := &guardianv1alpha1.Tenant{}
+			Expect(k8sClient.Get(ctx, tenantNamespacedName, t)).To(Succeed())
+			t.Spec.Baseline = &guardianv1alpha1.TenantBaselineSpec{
+				Propagate: &guardianv1alpha1.TenantPropagateSpec{
+					Secrets:    []string{src.Name + "/regcred"},
+					ConfigMaps: []string{src.Name + "/ca-bundle"},
+				},
+			}
+			Expect(k8sClient.Update(ctx, t)).To(Succeed())
+			DeferCleanup(func() {
+				Expect(k8sClient.Get(ctx, tenantNamespacedName, t)).To(Succeed())
+				t.Spec.Baseline = nil
+				Expect(k8sClient.Update(ctx, t)).To(Succeed())
+			})
+
+			controllerReconciler := &NamespaceRequestReconciler{
+				Client:   k8sClient,
+				Scheme:   k8sClient.Scheme(),
+				Recorder: record.NewFakeRecorder(100),
+			}
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(k8sClient.Get(ctx, typeNamespacedName, namespacerequest)).To(Succeed())
+			Expect(meta.IsStatusConditionTrue(namespacerequest.Status.Conditions, guardianv1alpha1.CondPropagationReady)).To(BeTrue())
+			nsName := namespacerequest.Status.NamespaceName
+
+			copied := &corev1.Secret{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Namespace: nsName, Name: "regcred"}, copied)).To(Succeed())
+			Expect(copied.Data).To(HaveKeyWithValue("token", []byte("v1")))
+			Expect(copied.Labels).To(HaveKeyWithValue(guardianv1alpha1.LabelManaged, "true"))
+			Expect(copied.Labels).To(HaveKeyWithValue(guardianv1alpha1.LabelPropagation, guardianv1alpha1.PropagationCopy))
+			Expect(copied.Annotations).To(HaveKeyWithValue(guardianv1alpha1.AnnPropagatedFrom, src.Name+"/regcred"))
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Namespace: nsName, Name: "ca-bundle"}, &corev1.ConfigMap{})).To(Succeed())
+
+			By("following a change of the source secret")
+			secret.Data["token"] = []byte("v2")
+			Expect(k8sClient.Update(ctx, secret)).To(Succeed())
+			Expect(controllerReconciler.requestsForPropagationSource(ctx, secret)).To(ContainElement(
+				reconcile.Request{NamespacedName: typeNamespacedName}))
+			_, err = controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Namespace: nsName, Name: "regcred"}, copied)).To(Succeed())
+			Expect(copied.Data).To(HaveKeyWithValue("token", []byte("v2")))
+
+			By("removing the configmap reference")
+			Expect(k8sClient.Get(ctx, tenantNamespacedName, t)).To(Succeed())
+			t.Spec.Baseline.Propagate.ConfigMaps = nil
+			Expect(k8sClient.Update(ctx, t)).To(Succeed())
+			_, err = controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+			err = k8sClient.Get(ctx, types.NamespacedName{Namespace: nsName, Name: "ca-bundle"}, &corev1.ConfigMap{})
+			Expect(errors.IsNotFound(err)).To(BeTrue())
+
+			By("refusing to overwrite a configmap the tenant created itself")
+			own := &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: "ca-bundle", Namespace: nsName},
+				Data:       map[string]string{"ca.crt": "mine"},
+			}
+			Expect(k8sClient.Create(ctx, own)).To(Succeed())
+			Expect(k8sClient.Get(ctx, tenantNamespacedName, t)).To(Succeed())
+			t.Spec.Baseline.Propagate.ConfigMaps = []string{src.Name + "/ca-bundle"}
+			Expect(k8sClient.Update(ctx, t)).To(Succeed())
+			_, err = controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(k8sClient.Get(ctx, typeNamespacedName, namespacerequest)).To(Succeed())
+			Expect(namespacerequest.Status.Phase).To(Equal(guardianv1alpha1.PhaseFailed))
+			Expect(meta.IsStatusConditionFalse(namespacerequest.Status.Conditions, guardianv1alpha1.CondPropagationReady)).To(BeTrue())
+			Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(own), own)).To(Succeed())
+			Expect(own.Data).To(HaveKeyWithValue("ca.crt", "mine"))
+			Expect(own.Labels).NotTo(HaveKey(guardianv1alpha1.LabelManaged))
+
+			By("refusing sources the platform has not labeled for propagation")
+			Expect(k8sClient.Delete(ctx, own)).To(Succeed())
+			delete(cm.Labels, guardianv1alpha1.LabelPropagation)
+			Expect(k8sClient.Update(ctx, cm)).To(Succeed())
+			_, err = controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(k8sClient.Get(ctx, typeNamespacedName, namespacerequest)).To(Succeed())
+			Expect(namespacerequest.Status.Phase).To(Equal(guardianv1alpha1.PhaseFailed))
+			Expect(namespacerequest.Status.Message).To(ContainSubstring("is not labeled"))
+			err = k8sClient.Get(ctx, types.NamespacedName{Namespace: nsName, Name: "ca-bundle"}, &corev1.ConfigMap{})
+			Expect(errors.IsNotFound(err)).To(BeTrue())
+		})
+
+		It("should hold Tenant changes back until the rollout admits the namespace", func() {
+			t := &guardianv1alpha1.Tenant{}
+			Expect(k8sClient.Get(ctx, tenantNamespacedName, t)).To(Succeed())
+			t.Spec.Rollout = &guardianv1alpha1.TenantRolloutSpec{MaxPerMinute: 1}
+			t.Spec.Baseline = &guardianv1alpha1.TenantBaselineSpec{
+				Quota: &guardianv1alpha1.TenantQuotaSpec{Default: guardianv1alpha1.QuotaHard{Pods: "10"}},
+			}
+			Expect(k8sClient.Update(ctx, t)).To(Succeed())
+			DeferCleanup(func() {
+				Expect(k8sClient.Get(ctx, tenantNamespacedName, t)).To(Succeed())
+				t.Spec.Rollout = nil
+				t.Spec.Baseline = nil
+				Expect(k8sClient.Update(ctx, t)).To(Succeed())
+			})
+
+			controllerReconciler := &NamespaceRequestReconciler{
+				Client:   k8sClient,
+				Scheme:   k8sClient.Scheme(),
+				Recorder: record.NewFakeRecorder(100),
+			}
+			tenantReconciler := &TenantReconciler{Client: k8sClient, Scheme: k8sClient.Scheme()}
+			reconcileBoth := func() {
+				_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+				Expect(err).NotTo(HaveOccurred())
+				_, err = tenantReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: tenantNamespacedName})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(k8sClient.Get(ctx, typeNamespacedName, namespacerequest)).To(Succeed())
+				Expect(k8sClient.Get(ctx, tenantNamespacedName, t)).To(Succeed())
+			}
+			reconcileBoth()
+			Expect(t.Status.Rollout).NotTo(BeNil())
+			Expect(t.Status.Rollout.Phase).To(Equal(guardianv1alpha1.RolloutComplete))
+			rqKey := types.NamespacedName{Namespace: namespacerequest.Status.NamespaceName, Name: "guardian-rq-default"}
+			rq := &corev1.ResourceQuota{}
+			Expect(k8sClient.Get(ctx, rqKey, rq)).To(Succeed())
+			Expect(rq.Spec.Hard.Pods().String()).To(Equal("10"))
+
+			By("changing the tenant quota")
+			t.Spec.Baseline.Quota.Default.Pods = "12"
+			Expect(k8sClient.Update(ctx, t)).To(Succeed())
+			reconcileBoth()
+			Expect(namespacerequest.Status.Reason).To(Equal("RolloutPending"))
+			Expect(k8sClient.Get(ctx, rqKey, rq)).To(Succeed())
+			Expect(rq.Spec.Hard.Pods().String()).To(Equal("10"))
+			Expect(t.Status.Rollout.Phase).To(Equal(guardianv1alpha1.RolloutProgressing))
+			Expect(t.Status.Rollout.Admitted).To(ContainElement(HaveField("Request", resourceName)))
+			Expect(meta.FindStatusCondition(t.Status.Conditions, guardianv1alpha1.CondBaselineApplied).Reason).
+				To(Equal(ReasonRolloutInProgress))
+
+			By("applying the change once admitted")
+			reconcileBoth()
+			Expect(namespacerequest.Status.Reason).To(Equal("Provisioned"))
+			Expect(k8sClient.Get(ctx, rqKey, rq)).To(Succeed())
+			Expect(rq.Spec.Hard.Pods().String()).To(Equal("12"))
+			Expect(t.Status.Rollout.Phase).To(Equal(guardianv1alpha1.RolloutComplete))
+			Expect(t.Status.Rollout.Updated).To(Equal(t.Status.Rollout.Total))
+
+			By("applying a request change without waiting for the rollout")
+			t.Spec.Baseline.Quota.Default.Pods = "14"
+			Expect(k8sClient.Update(ctx, t)).To(Succeed())
+			namespacerequest.Spec.OwnerGroup = tenantName + ":ops"
+			Expect(k8sClient.Update(ctx, namespacerequest)).To(Succeed())
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(k8sClient.Get(ctx, typeNamespacedName, namespacerequest)).To(Succeed())
+			Expect(namespacerequest.Status.Reason).To(Equal("Provisioned"))
+			Expect(namespacerequest.Status.OwnerGroup).To(Equal(tenantName + ":ops"))
+			Expect(k8sClient.Get(ctx, rqKey, rq)).To(Succeed())
+			Expect(rq.Spec.Hard.Pods().String()).To(Equal("14"))
+		})
+
+		It("should not hold a request change back after a transient failure", func() {
+			t := &guardianv1alpha1.Tenant{}
+			Expect(k8sClient.Get(ctx, tenantNamespacedName, t)).To(Succeed())
+			t.Spec.Rollout = &guardianv1alpha1.TenantRolloutSpec{MaxPerMinute: 1}
+			t.Spec.Baseline = &guardianv1alpha1.TenantBaselineSpec{
+				Quota: &guardianv1alpha1.TenantQuotaSpec{Default: guardianv1alpha1.QuotaHard{Pods: "10"}},
+			}
+			Expect(k8sClient.Update(ctx, t)).To(Succeed())
+			DeferCleanup(func() {
+				Expect(k8sClient.Get(ctx, tenantNamespacedName, t)).To(Succeed())
+				t.Spec.Rollout = nil
+				t.Spec.Baseline = nil
+				Expect(k8sClient.Update(ctx, t)).To(Succeed())
+			})
+
+			controllerReconciler := &NamespaceRequestReconciler{
+				Client:   k8sClient,
+				Scheme:   k8sClient.Scheme(),
+				Recorder: record.NewFakeRecorder(100),
+			}
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(k8sClient.Get(ctx, typeNamespacedName, namespacerequest)).To(Succeed())
+			Expect(namespacerequest.Status.AppliedGeneration).To(Equal(namespacerequest.Generation))
+
+			By("changing the owner group and failing the first attempt transiently")
+			namespacerequest.Spec.OwnerGroup = tenantName + ":ops"
+			Expect(k8sClient.Update(ctx, namespacerequest)).To(Succeed())
+			namespacerequest.Status.Phase = guardianv1alpha1.PhaseRetrying
+			namespacerequest.Status.Reason = ReasonBaselineFailed
+			namespacerequest.Status.ObservedGeneration = namespacerequest.Generation
+			namespacerequest.Status.Retry = &guardianv1alpha1.RetryStatus{
+				Attempts:    1,
+				NextRetryAt: &metav1.Time{Time: time.Now().Add(-time.Second)},
+				LastError:   "timeout",
+			}
+			Expect(k8sClient.Status().Update(ctx, namespacerequest)).To(Succeed())
+
+			By("retrying while a Tenant change waits for the rollout")
+			Expect(k8sClient.Get(ctx, tenantNamespacedName, t)).To(Succeed())
+			t.Spec.Baseline.Quota.Default.Pods = "12"
+			Expect(k8sClient.Update(ctx, t)).To(Succeed())
+			_, err = controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(k8sClient.Get(ctx, typeNamespacedName, namespacerequest)).To(Succeed())
+			Expect(namespacerequest.Status.Reason).To(Equal("Provisioned"))
+			Expect(namespacerequest.Status.OwnerGroup).To(Equal(tenantName + ":ops"))
+			Expect(namespacerequest.Status.AppliedGeneration).To(Equal(namespacerequest.Generation))
+		})
+
+		It("should keep holding Tenant changes back after the Tenant is resumed", func() {
+			t := &guardianv1alpha1.Tenant{}
+			Expect(k8sClient.Get(ctx, tenantNamespacedName, t)).To(Succeed())
+			t.Spec.Rollout = &guardianv1alpha1.TenantRolloutSpec{MaxPerMinute: 1}
+			t.Spec.Baseline = &guardianv1alpha1.TenantBaselineSpec{
+				Quota: &guardianv1alpha1.TenantQuotaSpec{Default: guardianv1alpha1.QuotaHard{Pods: "10"}},
+			}
+			Expect(k8sClient.Update(ctx, t)).To(Succeed())
+			DeferCleanup(func() {
+				Expect(k8sClient.Get(ctx, tenantNamespacedName, t)).To(Succeed())
+				t.Spec.Rollout = nil
+				t.Spec.Baseline = nil
+				t.Spec.Suspend = false
+				Expect(k8sClient.Update(ctx, t)).To(Succeed())
+			})
+
+			controllerReconciler := &NamespaceRequestReconciler{
+				Client:   k8sClient,
+				Scheme:   k8sClient.Scheme(),
+				Recorder: record.NewFakeRecorder(100),
+			}
+			reconcileRequest := func() {
+				_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(k8sClient.Get(ctx, typeNamespacedName, namespacerequest)).To(Succeed())
+			}
+			reconcileRequest()
+			Expect(namespacerequest.Status.Phase).To(Equal(guardianv1alpha1.PhaseProvisioned))
+			rqKey := types.NamespacedName{Namespace: namespacerequest.Status.NamespaceName, Name: "guardian-rq-default"}
+			rq := &corev1.ResourceQuota{}
+
+			By("changing the quota while the Tenant is suspended")
+			Expect(k8sClient.Get(ctx, tenantNamespacedName, t)).To(Succeed())
+			t.Spec.Suspend = true
+			Expect(k8sClient.Update(ctx, t)).To(Succeed())
+			reconcileRequest()
+			Expect(namespacerequest.Status.Phase).To(Equal(guardianv1alpha1.PhaseSuspended))
+			Expect(k8sClient.Get(ctx, tenantNamespacedName, t)).To(Succeed())
+			t.Spec.Baseline.Quota.Default.Pods = "12"
+			Expect(k8sClient.Update(ctx, t)).To(Succeed())
+
+			By("waiting for the rollout after the Tenant is resumed")
+			Expect(k8sClient.Get(ctx, tenantNamespacedName, t)).To(Succeed())
+			t.Spec.Suspend = false
+			Expect(k8sClient.Update(ctx, t)).To(Succeed())
+			reconcileRequest()
+			Expect(namespacerequest.Status.Reason).To(Equal("RolloutPending"))
+			Expect(k8sClient.Get(ctx, rqKey, rq)).To(Succeed())
+			Expect(rq.Spec.Hard.Pods().String()).To(Equal("10"))
+
+			By("applying the change once admitted")
+			tenantReconciler := &TenantReconciler{Client: k8sClient, Scheme: k8sClient.Scheme()}
+			_, err := tenantReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: tenantNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+			reconcileRequest()
+			Expect(namespacerequest.Status.Phase).To(Equal(guardianv1alpha1.PhaseProvisioned))
+			Expect(k8sClient.Get(ctx, rqKey, rq)).To(Succeed())
+			Expect(rq.Spec.Hard.Pods().String()).To(Equal("12"))
+		})
+
+		It("should migrate the baseline from v1 to v2 and count namespaces per version", func() {
+			controllerReconciler := &NamespaceRequestReconciler{
+				Client:   k8sClient,
+				Scheme:   k8sClient.Scheme(),
+				Recorder: record.NewFakeRecorder(100),
+			}
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(k8sClient.Get(ctx, typeNamespacedName, namespacerequest)).To(Succeed())
+			nsName := namespacerequest.Status.NamespaceName
+
+			By("a tenant without a baseline version renders v1")
+			ns := &corev1.Namespace{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: nsName}, ns)).To(Succeed())
+			Expect(ns.Annotations).To(HaveKeyWithValue(guardianv1alpha1.AnnBaselineVersion, guardianv1alpha1.BaselineVersionV1))
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Namespace: nsName, Name: "guardian-tenant-admin.yaml"}, &rbacv1.RoleBinding{})).To(Succeed())
+
+			By("raising the tenant baseline version")
+			t := &guardianv1alpha1.Tenant{}
+			Expect(k8sClient.Get(ctx, tenantNamespacedName, t)).To(Succeed())
+			t.Spec.Baseline = &guardianv1alpha1.TenantBaselineSpec{Version: guardianv1alpha1.BaselineVersionV2}
+			Expect(k8sClient.Update(ctx, t)).To(Succeed())
+			DeferCleanup(func() {
+				Expect(k8sClient.Get(ctx, tenantNamespacedName, t)).To(Succeed())
+				t.Spec.Baseline = nil
+				Expect(k8sClient.Update(ctx, t)).To(Succeed())
+			})
+
+			_, err = controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(k8sClient.Get(ctx, typeNamespacedName, namespacerequest)).To(Succeed())
+			Expect(namespacerequest.Status.Phase).To(Equal(guardianv1alpha1.PhaseProvisioned))
+			Expect(meta.IsStatusConditionTrue(namespacerequest.Status.Conditions, guardianv1alpha1.CondBaselineVersionReady)).To(BeTrue())
+
+			rb := &rbacv1.RoleBinding{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Namespace: nsName, Name: "guardian-tenant-admin"}, rb)).To(Succeed())
+			Expect(rb.Subjects[0].Name).To(Equal(tenantName + ":ns-admin"))
+			err = k8sClient.Get(ctx, types.NamespacedName{Namespace: nsName, Name: "guardian-tenant-admin.yaml"}, &rbacv1.RoleBinding{})
+			Expect(errors.IsNotFound(err)).To(BeTrue())
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: nsName}, ns)).To(Succeed())
+			Expect(ns.Annotations).To(HaveKeyWithValue(guardianv1alpha1.AnnBaselineVersion, guardianv1alpha1.BaselineVersionV2))
+
+			By("the tenant status counts namespaces per baseline version")
+			tenantReconciler := &TenantReconciler{Client: k8sClient, Scheme: k8sClient.Scheme()}
+			_, err = tenantReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: tenantNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(k8sClient.Get(ctx, tenantNamespacedName, t)).To(Succeed())
+			Expect(t.Status.BaselineVersions).To(ContainElement(And(
+				HaveField("Version", guardianv1alpha1.BaselineVersionV2), HaveField("Namespaces", BeNumerically(">=", 1)))))
+		})
+
+		It("should apply, report and prune templated Tenant manifests", func() {
+			pdb := guardianv1alpha1.BaselineManifest{
+				Name: "default-pdb",
+				Template: `apiVersion: policy/v1
+kind: PodDisruptionBudget
+metadata:
+  name: "{tenant}-pdb"
+  labels:
+    team: "{env}"
+spec:
+  maxUnavailable: 1
+  selector:
+    matchLabels:
+      app.kubernetes.io/part-of: "{namespace}"
+`,
+			}
+			role := guardianv1alpha1.BaselineManifest{
+				Name: "pod-reader",
+				Template: `apiVersion: rbac.authorization.k8s.io/v1
+kind: Role
+metadata:
+  name: pod-reader
+rules:
+- apiGroups: [""]
+  resources: ["pods"]
+  verbs: ["get", "list"]
+`,
+			}
+			t := &guardianv1alpha1.Tenant{}
+			Expect(k8sClient.Get(ctx, tenantNamespacedName, t)).To(Succeed())
+			t.Spec.Baseline = &guardianv1alpha1.TenantBaselineSpec{
+				Manifests: []guardianv1alpha1.BaselineManifest{pdb, role},
+			}
+			Expect(k8sClient.Update(ctx, t)).To(Succeed())
+			DeferCleanup(func() {
+				Expect(k8sClient.Get(ctx, tenantNamespacedName, t)).To(Succeed())
+				t.Spec.Baseline = nil
+				Expect(k8sClient.Update(ctx, t)).To(Succeed())
+			})
+
+			controllerReconciler := &NamespaceRequestReconciler{
+				Client:   k8sClient,
+				Scheme:   k8sClient.Scheme(),
+				Recorder: record.NewFakeRecorder(100),
+			}
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(k8sClient.Get(ctx, typeNamespacedName, namespacerequest)).To(Succeed())
+			Expect(meta.IsStatusConditionTrue(namespacerequest.Status.Conditions, guardianv1alpha1.CondManifestsReady)).To(BeTrue())
+			Expect(namespacerequest.Status.Manifests).To(HaveLen(2))
+			Expect(namespacerequest.Status.Manifests[0]).To(Equal(guardianv1alpha1.ManifestStatus{
+				Name: "default-pdb", APIVersion: "policy/v1", Kind: "PodDisruptionBudget", ObjectName: tenantName + "-pdb", Applied: true,
+			}))
+			nsName := namespacerequest.Status.NamespaceName
+
+			got := &policyv1.PodDisruptionBudget{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Namespace: nsName, Name: tenantName + "-pdb"}, got)).To(Succeed())
+			Expect(got.Labels).To(HaveKeyWithValue("team", "dev"))
+			Expect(got.Labels).To(HaveKeyWithValue(guardianv1alpha1.LabelManaged, "true"))
+			Expect(got.Spec.Selector.MatchLabels).To(HaveKeyWithValue("app.kubernetes.io/part-of", nsName))
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Namespace: nsName, Name: "pod-reader"}, &rbacv1.Role{})).To(Succeed())
+
+			By("an unchanged second pass does not count drift")
+			_, err = controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(k8sClient.Get(ctx, typeNamespacedName, namespacerequest)).To(Succeed())
+			Expect(namespacerequest.Status.DriftCorrected).To(BeZero())
+
+			By("removing the PodDisruptionBudget manifest")
+			Expect(k8sClient.Get(ctx, tenantNamespacedName, t)).To(Succeed())
+			t.Spec.Baseline.Manifests = []guardianv1alpha1.BaselineManifest{role}
+			Expect(k8sClient.Update(ctx, t)).To(Succeed())
+			_, err = controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+			err = k8sClient.Get(ctx, types.NamespacedName{Namespace: nsName, Name: tenantName + "-pdb"}, got)
+			Expect(errors.IsNotFound(err)).To(BeTrue())
+			Expect(k8sClient.Get(ctx, typeNamespacedName, namespacerequest)).To(Succeed())
+			Expect(namespacerequest.Status.Manifests).To(HaveLen(1))
+
+			By("rejecting a cluster-scoped manifest")
+			Expect(k8sClient.Get(ctx, tenantNamespacedName, t)).To(Succeed())
+			t.Spec.Baseline.Manifests = append(t.Spec.Baseline.Manifests, guardianv1alpha1.BaselineManifest{
+				Name:     "global",
+				Template: "apiVersion: rbac.authorization.k8s.io/v1\nkind: ClusterRole\nmetadata:\n  name: \"{tenant}-global\"\n",
+			})
+			Expect(k8sClient.Update(ctx, t)).To(Succeed())
+			_, err = controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(k8sClient.Get(ctx, typeNamespacedName, namespacerequest)).To(Succeed())
+			Expect(namespacerequest.Status.Phase).To(Equal(guardianv1alpha1.PhaseFailed))
+			Expect(meta.IsStatusConditionFalse(namespacerequest.Status.Conditions, guardianv1alpha1.CondManifestsReady)).To(BeTrue())
+			Expect(namespacerequest.Status.Manifests).To(ContainElement(And(
+				HaveField("Name", "global"), HaveField("Applied", false), HaveField("Message", ContainSubstring("cluster-scoped")))))
+		})
+
+		It("should keep foreign fields and surface server-side apply conflicts", func() {
+			controllerReconciler := &NamespaceRequestReconciler{
+				Client:   k8sClient,
+				Scheme:   k8sClient.Scheme(),
+				Recorder: record.NewFakeRecorder(100),
+			}
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(k8sClient.Get(ctx, typeNamespacedName, namespacerequest)).To(Succeed())
+			key := types.NamespacedName{Namespace: namespacerequest.Status.NamespaceName, Name: "guardian-rq-default"}
+			rq := &corev1.ResourceQuota{}
+			Expect(k8sClient.Get(ctx, key, rq)).To(Succeed())
+			pods := rq.Spec.Hard.Pods().String()
+
+			By("another writer adds a quota key and changes a guardian-owned one")
+			rq.Spec.Hard["count/deployments.apps"] = resource.MustParse("5")
+			rq.Spec.Hard[corev1.ResourcePods] = resource.MustParse("999")
+			Expect(k8sClient.Update(ctx, rq, client.FieldOwner("other-controller"))).To(Succeed())
+
+			By("a Report policy keeps the foreign value and fails the component")
+			t := &guardianv1alpha1.Tenant{}
+			Expect(k8sClient.Get(ctx, tenantNamespacedName, t)).To(Succeed())
+			t.Spec.Baseline = &guardianv1alpha1.TenantBaselineSpec{
+				Conflicts: &guardianv1alpha1.TenantConflictSpec{
+					ByComponent: map[string]string{"Quota": guardianv1alpha1.ConflictPolicyReport},
+				},
+			}
+			Expect(k8sClient.Update(ctx, t)).To(Succeed())
+			DeferCleanup(func() {
+				Expect(k8sClient.Get(ctx, tenantNamespacedName, t)).To(Succeed())
+				t.Spec.Baseline = nil
+				Expect(k8sClient.Update(ctx, t)).To(Succeed())
+			})
+
+			_, err = controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(k8sClient.Get(ctx, typeNamespacedName, namespacerequest)).To(Succeed())
+			Expect(namespacerequest.Status.Phase).To(Equal(guardianv1alpha1.PhaseFailed))
+			cond := meta.FindStatusCondition(namespacerequest.Status.Conditions, guardianv1alpha1.CondQuotaReady)
+			Expect(cond).NotTo(BeNil())
+			Expect(cond.Status).To(Equal(metav1.ConditionFalse))
+			Expect(cond.Reason).To(Equal("FieldConflict"))
+			Expect(k8sClient.Get(ctx, key, rq)).To(Succeed())
+			Expect(rq.Spec.Hard.Pods().String()).To(Equal("999"))
+
+			By("the default Force policy takes the field back and reports it")
+			Expect(k8sClient.Get(ctx, tenantNamespacedName, t)).To(Succeed())
+			t.Spec.Baseline = nil
+			Expect(k8sClient.Update(ctx, t)).To(Succeed())
+
+			_, err = controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(k8sClient.Get(ctx, typeNamespacedName, namespacerequest)).To(Succeed())
+			Expect(namespacerequest.Status.Phase).To(Equal(guardianv1alpha1.PhaseProvisioned))
+			cond = meta.FindStatusCondition(namespacerequest.Status.Conditions, guardianv1alpha1.CondQuotaReady)
+			Expect(cond).NotTo(BeNil())
+			Expect(cond.Status).To(Equal(metav1.ConditionTrue))
+			Expect(cond.Reason).To(Equal("ConflictsForced"))
+			Expect(k8sClient.Get(ctx, key, rq)).To(Succeed())
+			Expect(rq.Spec.Hard.Pods().String()).To(Equal(pods))
+			Expect(rq.Spec.Hard).To(HaveKey(corev1.ResourceName("count/deployments.apps")))
+		})
+
+		It("should take over fields written before the operator used server-side apply", func() {
+			controllerReconciler := &NamespaceRequestReconciler{
+				Client:   k8sClient,
+				Scheme:   k8sClient.Scheme(),
+				Recorder: record.NewFakeRecorder(100),
+			}
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(k8sClient.Get(ctx, typeNamespacedName, namespacerequest)).To(Succeed())
+			key := types.NamespacedName{Namespace: namespacerequest.Status.NamespaceName, Name: "guardian-rq-default"}
+			rq := &corev1.ResourceQuota{}
+			Expect(k8sClient.Get(ctx, key, rq)).To(Succeed())
+			pods := rq.Spec.Hard.Pods().String()
+
+			By("rewriting the quota as the old Update-based operator did")
+			rq.Spec.Hard[corev1.ResourcePods] = resource.MustParse("7")
+			rq.Spec.Hard["count/jobs.batch"] = resource.MustParse("3")
+			Expect(k8sClient.Update(ctx, rq, client.FieldOwner("manager"))).To(Succeed())
+			Expect(k8sClient.Get(ctx, key, rq)).To(Succeed())
+			rq.ManagedFields = slices.DeleteFunc(rq.ManagedFields, func(e metav1.ManagedFieldsEntry) bool {
+				return e.Manager == FieldManager
+			})
+			Expect(k8sClient.Update(ctx, rq, client.FieldOwner("manager"))).To(Succeed())
+
+			By("applying under a Report policy without conflicting with the old writes")
+			t := &guardianv1alpha1.Tenant{}
+			Expect(k8sClient.Get(ctx, tenantNamespacedName, t)).To(Succeed())
+			t.Spec.Baseline = &guardianv1alpha1.TenantBaselineSpec{
+				Conflicts: &guardianv1alpha1.TenantConflictSpec{
+					ByComponent: map[string]string{"Quota": guardianv1alpha1.ConflictPolicyReport},
+				},
+			}
+			Expect(k8sClient.Update(ctx, t)).To(Succeed())
+			DeferCleanup(func() {
+				Expect(k8sClient.Get(ctx, tenantNamespacedName, t)).To(Succeed())
+				t.Spec.Baseline = nil
+				Expect(k8sClient.Update(ctx, t)).To(Succeed())
+			})
+
+			_, err = controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(k8sClient.Get(ctx, typeNamespacedName, namespacerequest)).To(Succeed())
+			Expect(namespacerequest.Status.Phase).To(Equal(guardianv1alpha1.PhaseProvisioned))
+			Expect(k8sClient.Get(ctx, key, rq)).To(Succeed())
+			Expect(rq.Spec.Hard.Pods().String()).To(Equal(pods))
+			Expect(rq.Spec.Hard).NotTo(HaveKey(corev1.ResourceName("count/jobs.batch")))
+			Expect(rq.ManagedFields).NotTo(ContainElement(HaveField("Manager", "manager")))
+		})
+
+		It("should prune baseline objects the Tenant no longer asks for, except protected and taken-over ones", func() {
+			controllerReconciler := &NamespaceRequestReconciler{
+				Client:   k8sClient,
+				Scheme:   k8sClient.Scheme(),
+				Recorder: record.NewFakeRecorder(100),
+			}
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(k8sClient.Get(ctx, typeNamespacedName, namespacerequest)).To(Succeed())
+			nsName := namespacerequest.Status.NamespaceName
+
+			By("protecting the DNS policy")
+			np := &networkingv1.NetworkPolicy{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Namespace: nsName, Name: "guardian-np-allow-dns"}, np)).To(Succeed())
+			np.Annotations[guardianv1alpha1.AnnProtect] = "true"
+			Expect(k8sClient.Update(ctx, np)).To(Succeed())
+
+			By("repairing a same-namespace policy whose managed label was stripped, without marking it taken over")
+			sameNS := &networkingv1.NetworkPolicy{}
+			sameNSKey := types.NamespacedName{Namespace: nsName, Name: "guardian-np-allow-same-namespace"}
+			Expect(k8sClient.Get(ctx, sameNSKey, sameNS)).To(Succeed())
+			delete(sameNS.Labels, guardianv1alpha1.LabelManaged)
+			Expect(k8sClient.Update(ctx, sameNS)).To(Succeed())
+			_, err = controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(k8sClient.Get(ctx, typeNamespacedName, namespacerequest)).To(Succeed())
+			Expect(namespacerequest.Status.Phase).To(Equal(guardianv1alpha1.PhaseProvisioned))
+			Expect(k8sClient.Get(ctx, sameNSKey, sameNS)).To(Succeed())
+			Expect(sameNS.Labels).To(HaveKeyWithValue(guardianv1alpha1.LabelManaged, "true"))
+			Expect(sameNS.Annotations).NotTo(HaveKey(guardianv1alpha1.AnnTakenOver))
+
+			By("keeping the taken-over mark on a policy an adoption took over")
+			metav1.SetMetaDataAnnotation(&sameNS.ObjectMeta, guardianv1alpha1.AnnTakenOver, "true")
+			Expect(k8sClient.Update(ctx, sameNS)).To(Succeed())
+			_, err = controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(k8sClient.Get(ctx, typeNamespacedName, namespacerequest)).To(Succeed())
+			Expect(namespacerequest.Status.Phase).To(Equal(guardianv1alpha1.PhaseProvisioned))
+			Expect(k8sClient.Get(ctx, sameNSKey, sameNS)).To(Succeed())
+			Expect(sameNS.Annotations).To(HaveKeyWithValue(guardianv1alpha1.AnnTakenOver, "true"))
+
+			By("leaving objects the controller created, including by earlier releases, unmarked")
+			denyKey := types.NamespacedName{Namespace: nsName, Name: "guardian-np-default-deny"}
+			deny := &networkingv1.NetworkPolicy{}
+			Expect(k8sClient.Get(ctx, denyKey, deny)).To(Succeed())
+			Expect(deny.Annotations).NotTo(HaveKey(guardianv1alpha1.AnnTakenOver))
+			legacy := &networkingv1.NetworkPolicy{ObjectMeta: metav1.ObjectMeta{
+				Name:      "guardian-np-legacy",
+				Namespace: nsName,
+				Labels: map[string]string{
+					guardianv1alpha1.LabelManaged:     "true",
+					guardianv1alpha1.LabelRequestHash: guardianv1alpha1.ShortHash16(resourceName),
+				},
+			}}
+			Expect(k8sClient.Create(ctx, legacy)).To(Succeed())
+
+			By("switching the tenant to the open profile and a smaller quota")
+			t := &guardianv1alpha1.Tenant{}
+			Expect(k8sClient.Get(ctx, tenantNamespacedName, t)).To(Succeed())
+			t.Spec.Baseline = &guardianv1alpha1.TenantBaselineSpec{
+				NetworkPolicy: &guardianv1alpha1.TenantNetworkPolicySpec{Profile: guardianv1alpha1.NPProfileOpen},
+				Quota:         &guardianv1alpha1.TenantQuotaSpec{Default: guardianv1alpha1.QuotaHard{Pods: "10"}},
+			}
+			Expect(k8sClient.Update(ctx, t)).To(Succeed())
+			DeferCleanup(func() {
+				Expect(k8sClient.Get(ctx, tenantNamespacedName, t)).To(Succeed())
+				t.Spec.Baseline = nil
+				Expect(k8sClient.Update(ctx, t)).To(Succeed())
+			})
+
+			_, err = controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(k8sClient.Get(ctx, typeNamespacedName, namespacerequest)).To(Succeed())
+			Expect(namespacerequest.Status.Phase).To(Equal(guardianv1alpha1.PhaseProvisioned))
+
+			Expect(errors.IsNotFound(k8sClient.Get(ctx, denyKey, deny))).To(BeTrue())
+			Expect(errors.IsNotFound(k8sClient.Get(ctx, client.ObjectKeyFromObject(legacy), legacy))).To(BeTrue())
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Namespace: nsName, Name: "guardian-np-allow-dns"}, np)).To(Succeed())
+			Expect(k8sClient.Get(ctx, sameNSKey, sameNS)).To(Succeed())
+
+			rq := &corev1.ResourceQuota{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Namespace: nsName, Name: "guardian-rq-default"}, rq)).To(Succeed())
+			Expect(rq.Spec.Hard.Pods().String()).To(Equal("10"))
+			Expect(rq.Spec.Hard).NotTo(HaveKey(corev1.ResourceServices))
+		})
+
+		It("should render the baseline from the Tenant baseline spec", func() {
+			controllerReconciler := &NamespaceRequestReconciler{
+				Client:   k8sClient,
+				Scheme:   k8sClient.Scheme(),
+				Recorder: record.NewFakeRecorder(100),
+			}
+
+			By("configuring a strict profile and a dev limit range override")
+			t := &guardianv1alpha1.Tenant{}
+			Expect(k8sClient.Get(ctx, tenantNamespacedName, t)).To(Succeed())
+			t.Spec.Baseline = &guardianv1alpha1.TenantBaselineSpec{
+				LimitRange: &guardianv1alpha1.TenantLimitRangeSpec{
+					ByEnv: map[string]guardianv1alpha1.LimitRangeHard{
+						"dev": {DefaultRequestCPU: "50m", MaxCPU: "2"},
+					},
+				},
+				NetworkPolicy: &guardianv1alpha1.TenantNetworkPolicySpec{
+					Profile:          guardianv1alpha1.NPProfileStrict,
+					AllowEgressCIDRs: []string{"10.0.0.0/8"},
+				},
+			}
+			Expect(k8sClient.Update(ctx, t)).To(Succeed())
+
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(k8sClient.Get(ctx, typeNamespacedName, namespacerequest)).To(Succeed())
+			ns := namespacerequest.Status.NamespaceName
+
+			lr := &corev1.LimitRange{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Namespace: ns, Name: "guardian-lr-default"}, lr)).To(Succeed())
+			Expect(lr.Spec.Limits).To(HaveLen(1))
+			Expect(lr.Spec.Limits[0].DefaultRequest.Cpu().String()).To(Equal("50m"))
+			Expect(lr.Spec.Limits[0].Max.Cpu().String()).To(Equal("2"))
+
+			np := &networkingv1.NetworkPolicy{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Namespace: ns, Name: "guardian-np-allow-egress-cidrs"}, np)).To(Succeed())
+			Expect(np.Spec.Egress[0].To[0].IPBlock.CIDR).To(Equal("10.0.0.0/8"))
+		})
+
+		It("should fail when the generated name does not match namespaceNamePattern", func() {
+			t := &guardianv1alpha1.Tenant{}
+			Expect(k8sClient.Get(ctx, tenantNamespacedName, t)).To(Succeed())
+			t.Spec.NamespaceNamePattern = "^prod-only-.*$"
+			Expect(k8sClient.Update(ctx, t)).To(Succeed())
+			DeferCleanup(func() {
+				Expect(k8sClient.Get(ctx, tenantNamespacedName, t)).To(Succeed())
+				t.Spec.NamespaceNamePattern = ""
+				Expect(k8sClient.Update(ctx, t)).To(Succeed())
+			})
+
+			controllerReconciler := &NamespaceRequestReconciler{
+				Client:   k8sClient,
+				Scheme:   k8sClient.Scheme(),
+				Recorder: record.NewFakeRecorder(100),
+			}
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(k8sClient.Get(ctx, typeNamespacedName, namespacerequest)).To(Succeed())
+			Expect(namespacerequest.Status.Phase).To(Equal(guardianv1alpha1.PhaseFailed))
+			Expect(namespacerequest.Status.Reason).To(Equal("InvalidNamespaceName"))
+			Expect(namespacerequest.Status.NamespaceName).To(BeEmpty())
+		})
+
+		It("should back off on transient failures and retry on demand", func() {
+			failing := &flakyClient{Client: k8sClient, err: errors.NewServiceUnavailable("apiserver overloaded")}
+			controllerReconciler := &NamespaceRequestReconciler{
+				Client:   failing,
+				Scheme:   k8sClient.Scheme(),
+				Recorder: record.NewFakeRecorder(100),
+			}
+
+			By("failing the namespace create with a transient error")
+			res, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(res.RequeueAfter).To(Equal(retryBaseDelay))
+			Expect(k8sClient.Get(ctx, typeNamespacedName, namespacerequest)).To(Succeed())
+			Expect(namespacerequest.Status.Phase).To(Equal(guardianv1alpha1.PhaseRetrying))
+			Expect(namespacerequest.Status.Retry).NotTo(BeNil())
+			Expect(namespacerequest.Status.Retry.Attempts).To(Equal(int32(1)))
+			Expect(namespacerequest.Status.Retry.NextRetryAt).NotTo(BeNil())
+
+			By("waiting out the backoff instead of retrying immediately")
+			failing.err = nil
+			res, err = controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(res.RequeueAfter).To(BeNumerically(">", 0))
+			Expect(k8sClient.Get(ctx, typeNamespacedName, namespacerequest)).To(Succeed())
+			Expect(namespacerequest.Status.Phase).To(Equal(guardianv1alpha1.PhaseRetrying))
+
+			By("forcing a retry with the annotation")
+			namespacerequest.Annotations = map[string]string{guardianv1alpha1.AnnRetry: "now"}
+			Expect(k8sClient.Update(ctx, namespacerequest)).To(Succeed())
+			_, err = controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(k8sClient.Get(ctx, typeNamespacedName, namespacerequest)).To(Succeed())
+			Expect(namespacerequest.Status.Phase).To(Equal(guardianv1alpha1.PhaseProvisioned))
+			Expect(namespacerequest.Status.Retry).To(BeNil())
+			Expect(namespacerequest.Annotations).NotTo(HaveKey(guardianv1alpha1.AnnRetry))
+		})
+
+		It("should stay Failed without requeue on a terminal baseline error", func() {
+			t := &guardianv1alpha1.Tenant{}
+			Expect(k8sClient.Get(ctx, tenantNamespacedName, t)).To(Succeed())
+			t.Spec.Baseline = &guardianv1alpha1.TenantBaselineSpec{
+				Quota: &guardianv1alpha1.TenantQuotaSpec{Default: guardianv1alpha1.QuotaHard{Pods: "lots"}},
+			}
+			Expect(k8sClient.Update(ctx, t)).To(Succeed())
+			DeferCleanup(func() {
+				Expect(k8sClient.Get(ctx, tenantNamespacedName, t)).To(Succeed())
+				t.Spec.Baseline = nil
+				Expect(k8sClient.Update(ctx, t)).To(Succeed())
+			})
+
+			controllerReconciler := &NamespaceRequestReconciler{
+				Client:   k8sClient,
+				Scheme:   k8sClient.Scheme(),
+				Recorder: record.NewFakeRecorder(100),
+			}
+			res, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(res.RequeueAfter).To(BeZero())
+
+			Expect(k8sClient.Get(ctx, typeNamespacedName, namespacerequest)).To(Succeed())
+			Expect(namespacerequest.Status.Phase).To(Equal(guardianv1alpha1.PhaseFailed))
+			Expect(namespacerequest.Status.Reason).To(Equal("BaselineFailed"))
+			Expect(namespacerequest.Status.Retry).To(BeNil())
+		})
+
+		It("should fail without retrying when the controller is forbidden", func() {
+			controllerReconciler := &NamespaceRequestReconciler{
+				Client: &flakyClient{Client: k8sClient, err: errors.NewForbidden(
+					corev1.Resource("namespaces"), "nr-test", fmt.Errorf("denied by admission webhook"))},
+				Scheme:   k8sClient.Scheme(),
+				Recorder: record.NewFakeRecorder(100),
+			}
+			res, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(res.RequeueAfter).To(BeZero())
+
+			Expect(k8sClient.Get(ctx, typeNamespacedName, namespacerequest)).To(Succeed())
+			Expect(namespacerequest.Status.Phase).To(Equal(guardianv1alpha1.PhaseFailed))
+			Expect(namespacerequest.Status.Reason).To(Equal("NamespaceCreateFailed"))
+			Expect(namespacerequest.Status.Retry).To(BeNil())
+		})
+
+		It("should back off instead of failing while the namespace is terminating", func() {
+			By("retrying a create rejected because the namespace is being deleted")
+			terminating := errors.NewForbidden(corev1.Resource("resourcequotas"), "guardian-quota",
+				fmt.Errorf("unable to create new content in namespace because it is being terminated"))
+			terminating.ErrStatus.Details.Causes = []metav1.StatusCause{{Type: corev1.NamespaceTerminatingCause}}
+			controllerReconciler := &NamespaceRequestReconciler{
+				Client:   &flakyClient{Client: k8sClient, err: terminating},
+				Scheme:   k8sClient.Scheme(),
+				Recorder: record.NewFakeRecorder(100),
+			}
+			res, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(res.RequeueAfter).To(Equal(retryBaseDelay))
+			Expect(k8sClient.Get(ctx, typeNamespacedName, namespacerequest)).To(Succeed())
+			Expect(namespacerequest.Status.Phase).To(Equal(guardianv1alpha1.PhaseRetrying))
+
+			By("not claiming a namespace that has a deletionTimestamp")
+			controllerReconciler = &NamespaceRequestReconciler{
+				Client:   &terminatingNamespaceClient{Client: k8sClient},
+				Scheme:   k8sClient.Scheme(),
+				Recorder: record.NewFakeRecorder(100),
+			}
+			namespacerequest.Annotations = map[string]string{guardianv1alpha1.AnnRetry: "now"}
+			Expect(k8sClient.Update(ctx, namespacerequest)).To(Succeed())
+			res, err = controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(res.RequeueAfter).To(BeNumerically(">", 0))
+			Expect(k8sClient.Get(ctx, typeNamespacedName, namespacerequest)).To(Succeed())
+			Expect(namespacerequest.Status.Phase).To(Equal(guardianv1alpha1.PhaseRetrying))
+			Expect(namespacerequest.Status.Reason).To(Equal("NamespaceTerminating"))
+		})
+
+		It("should wait for approval before provisioning", func() {
+			t := &guardianv1alpha1.Tenant{}
+			Expect(k8sClient.Get(ctx, tenantNamespacedName, t)).To(Succeed())
+			t.Spec.Approval = &guardianv1alpha1.TenantApprovalSpec{Envs: []string{"dev"}}
+			Expect(k8sClient.Update(ctx, t)).To(Succeed())
+			DeferCleanup(func() {
+				Expect(k8sClient.Get(ctx, tenantNamespacedName, t)).To(Succeed())
+				t.Spec.Approval = nil
+				Expect(k8sClient.Update(ctx, t)).To(Succeed())
+			})
+
+			controllerReconciler := &NamespaceRequestReconciler{
+				Client:   k8sClient,
+				Scheme:   k8sClient.Scheme(),
+				Recorder: record.NewFakeRecorder(100),
+			}
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(k8sClient.Get(ctx, typeNamespacedName, namespacerequest)).To(Succeed())
+			Expect(namespacerequest.Status.Phase).To(Equal(guardianv1alpha1.PhaseAwaitingApproval))
+			Expect(namespacerequest.Status.NamespaceName).To(BeEmpty())
+
+			By("a self-approval does not count")
+			namespacerequest.Annotations = map[string]string{
+				guardianv1alpha1.AnnRequestedBy: "alice",
+				guardianv1alpha1.AnnApprovedBy:  "alice",
+			}
+			Expect(k8sClient.Update(ctx, namespacerequest)).To(Succeed())
+			_, err = controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(k8sClient.Get(ctx, typeNamespacedName, namespacerequest)).To(Succeed())
+			Expect(namespacerequest.Status.Phase).To(Equal(guardianv1alpha1.PhaseAwaitingApproval))
+
+			By("an approval from someone else provisions the namespace")
+			namespacerequest.Annotations[guardianv1alpha1.AnnApprovedBy] = "alice,bob"
+			Expect(k8sClient.Update(ctx, namespacerequest)).To(Succeed())
+			_, err = controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(k8sClient.Get(ctx, typeNamespacedName, namespacerequest)).To(Succeed())
+			Expect(namespacerequest.Status.Phase).To(Equal(guardianv1alpha1.PhaseProvisioned))
+			Expect(meta.IsStatusConditionTrue(namespacerequest.Status.Conditions, guardianv1alpha1.CondApproved)).To(BeTrue())
+		})
+
+		It("should publish expiresAt and warn before the TTL runs out", func() {
+			t := &guardianv1alpha1.Tenant{}
+			Expect(k8sClient.Get(ctx, tenantNamespacedName, t)).To(Succeed())
+			t.Spec.TTL = &guardianv1alpha1.TenantTTLSpec{WarnBefore: &metav1.Duration{Duration: 2 * time.Hour}}
+			Expect(k8sClient.Update(ctx, t)).To(Succeed())
+			DeferCleanup(func() {
+				Expect(k8sClient.Get(ctx, tenantNamespacedName, t)).To(Succeed())
+				t.Spec.TTL = nil
+				Expect(k8sClient.Update(ctx, t)).To(Succeed())
+			})
+
+			Expect(k8sClient.Get(ctx, typeNamespacedName, namespacerequest)).To(Succeed())
+			namespacerequest.Spec.TTL = &metav1.Duration{Duration: time.Hour}
+			Expect(k8sClient.Update(ctx, namespacerequest)).To(Succeed())
+
+			controllerReconciler := &NamespaceRequestReconciler{
+				Client:   k8sClient,
+				Scheme:   k8sClient.Scheme(),
+				Recorder: record.NewFakeRecorder(100),
+			}
+			res, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(res.RequeueAfter).To(BeNumerically(">", 0))
+			Expect(res.RequeueAfter).To(BeNumerically("<=", time.Hour))
+
+			Expect(k8sClient.Get(ctx, typeNamespacedName, namespacerequest)).To(Succeed())
+			Expect(namespacerequest.Status.Phase).To(Equal(guardianv1alpha1.PhaseProvisioned))
+			Expect(namespacerequest.Status.ExpiresAt).NotTo(BeNil())
+			expiring := meta.FindStatusCondition(namespacerequest.Status.Conditions, guardianv1alpha1.CondExpiring)
+			Expect(expiring).NotTo(BeNil())
+			Expect(expiring.Status).To(Equal(metav1.ConditionTrue))
+			Expect(expiring.Reason).To(Equal("ExpiringSoon"))
+		})
+
+		It("should keep the TTL running when the Tenant is missing", func() {
+			Expect(k8sClient.Get(ctx, typeNamespacedName, namespacerequest)).To(Succeed())
+			namespacerequest.Spec.Tenant = "missing-tenant"
+			namespacerequest.Spec.TTL = &metav1.Duration{Duration: time.Hour}
+			Expect(k8sClient.Update(ctx, namespacerequest)).To(Succeed())
+
+			controllerReconciler := &NamespaceRequestReconciler{
+				Client:   k8sClient,
+				Scheme:   k8sClient.Scheme(),
+				Recorder: record.NewFakeRecorder(100),
+			}
+			res, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(res.RequeueAfter).To(BeNumerically(">", 0))
+			Expect(res.RequeueAfter).To(BeNumerically("<=", time.Hour))
+
+			Expect(k8sClient.Get(ctx, typeNamespacedName, namespacerequest)).To(Succeed())
+			Expect(namespacerequest.Status.Phase).To(Equal(guardianv1alpha1.PhaseFailed))
+			Expect(namespacerequest.Status.Reason).To(Equal("TenantNotFound"))
+			Expect(namespacerequest.Status.ExpiresAt).NotTo(BeNil())
+		})
+
+		It("should pause and resume when the Tenant is suspended", func() {
+			controllerReconciler := &NamespaceRequestReconciler{
+				Client:   k8sClient,
+				Scheme:   k8sClient.Scheme(),
+				Recorder: record.NewFakeRecorder(100),
+			}
+
+			By("suspending the tenant")
+			t := &guardianv1alpha1.Tenant{}
+			Expect(k8sClient.Get(ctx, tenantNamespacedName, t)).To(Succeed())
+			t.Spec.Suspend = true
+			Expect(k8sClient.Update(ctx, t)).To(Succeed())
+
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(k8sClient.Get(ctx, typeNamespacedName, namespacerequest)).To(Succeed())
+			Expect(namespacerequest.Status.Phase).To(Equal(guardianv1alpha1.PhaseSuspended))
+
+			By("lifting suspend")
+			Expect(k8sClient.Get(ctx, tenantNamespacedName, t)).To(Succeed())
+			t.Spec.Suspend = false
+			Expect(k8sClient.Update(ctx, t)).To(Succeed())
+
+			_, err = controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(k8sClient.Get(ctx, typeNamespacedName, namespacerequest)).To(Succeed())
+			Expect(namespacerequest.Status.Phase).To(Equal(guardianv1alpha1.PhaseProvisioned))
+		})
+
+		It("should retain the namespace as orphaned when the request is deleted", func() {
+			controllerReconciler := &NamespaceRequestReconciler{
+				Client:   k8sClient,
+				Scheme:   k8sClient.Scheme(),
+				Recorder: record.NewFakeRecorder(100),
+			}
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(k8sClient.Get(ctx, typeNamespacedName, namespacerequest)).To(Succeed())
+			Expect(namespacerequest.Finalizers).To(ContainElement(guardianv1alpha1.FinalizerNamespaceRequest))
+			nsName := namespacerequest.Status.NamespaceName
+
+			By("deleting the request")
+			Expect(k8sClient.Delete(ctx, namespacerequest)).To(Succeed())
+			_, err = controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(errors.IsNotFound(k8sClient.Get(ctx, typeNamespacedName, namespacerequest))).To(BeTrue())
+
+			ns := &corev1.Namespace{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: nsName}, ns)).To(Succeed())
+			Expect(ns.Labels).To(HaveKeyWithValue(guardianv1alpha1.LabelOrphaned, "true"))
+			Expect(ns.Labels).NotTo(HaveKey(guardianv1alpha1.LabelManaged))
+		})
+
+		It("should hold namespace reclaim while the Tenant is suspended", func() {
+			controllerReconciler := &NamespaceRequestReconciler{
+				Client:   k8sClient,
+				Scheme:   k8sClient.Scheme(),
+				Recorder: record.NewFakeRecorder(100),
+			}
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(k8sClient.Get(ctx, typeNamespacedName, namespacerequest)).To(Succeed())
+			nsName := namespacerequest.Status.NamespaceName
+
+			By("suspending the tenant and deleting the request")
+			t := &guardianv1alpha1.Tenant{}
+			Expect(k8sClient.Get(ctx, tenantNamespacedName, t)).To(Succeed())
+			t.Spec.Suspend = true
+			Expect(k8sClient.Update(ctx, t)).To(Succeed())
+			DeferCleanup(func() {
+				Expect(k8sClient.Get(ctx, tenantNamespacedName, t)).To(Succeed())
+				t.Spec.Suspend = false
+				Expect(k8sClient.Update(ctx, t)).To(Succeed())
+			})
+			Expect(k8sClient.Delete(ctx, namespacerequest)).To(Succeed())
+
+			_, err = controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(k8sClient.Get(ctx, typeNamespacedName, namespacerequest)).To(Succeed())
+			Expect(namespacerequest.Finalizers).To(ContainElement(guardianv1alpha1.FinalizerNamespaceRequest))
+			Expect(namespacerequest.Status.Phase).To(Equal(guardianv1alpha1.PhaseSuspended))
+			ns := &corev1.Namespace{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: nsName}, ns)).To(Succeed())
+			Expect(ns.Labels).To(HaveKeyWithValue(guardianv1alpha1.LabelManaged, "true"))
+
+			By("lifting suspend")
+			Expect(k8sClient.Get(ctx, tenantNamespacedName, t)).To(Succeed())
+			t.Spec.Suspend = false
+			Expect(k8sClient.Update(ctx, t)).To(Succeed())
+
+			_, err = controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(errors.IsNotFound(k8sClient.Get(ctx, typeNamespacedName, namespacerequest))).To(BeTrue())
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: nsName}, ns)).To(Succeed())
+			Expect(ns.Labels).To(HaveKeyWithValue(guardianv1alpha1.LabelOrphaned, "true"))
+		})
+
+		It("should reclaim the namespace of a request whose baseline failed", func() {
+			controllerReconciler := &NamespaceRequestReconciler{
+				Client:   k8sClient,
+				Scheme:   k8sClient.Scheme(),
+				Recorder: record.NewFakeRecorder(100),
+			}
+
+			By("breaking the tenant quota")
+			t := &guardianv1alpha1.Tenant{}
+			Expect(k8sClient.Get(ctx, tenantNamespacedName, t)).To(Succeed())
+			previous := t.Spec.Baseline.DeepCopy()
+			t.Spec.Baseline = &guardianv1alpha1.TenantBaselineSpec{
+				Quota: &guardianv1alpha1.TenantQuotaSpec{Default: guardianv1alpha1.QuotaHard{Pods: "not-a-quantity"}},
+			}
+			Expect(k8sClient.Update(ctx, t)).To(Succeed())
+			DeferCleanup(func() {
+				Expect(k8sClient.Get(ctx, tenantNamespacedName, t)).To(Succeed())
+				t.Spec.Baseline = previous
+				Expect(k8sClient.Update(ctx, t)).To(Succeed())
+			})
+
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(k8sClient.Get(ctx, typeNamespacedName, namespacerequest)).To(Succeed())
+			Expect(namespacerequest.Status.Phase).To(Equal(guardianv1alpha1.PhaseFailed))
+			Expect(namespacerequest.Status.Reason).To(Equal("BaselineFailed"))
+			nsName := namespacerequest.Status.NamespaceName
+			Expect(nsName).NotTo(BeEmpty())
+
+			By("reclaiming the namespace when the failed request is deleted")
+			Expect(k8sClient.Delete(ctx, namespacerequest)).To(Succeed())
+			_, err = controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(errors.IsNotFound(k8sClient.Get(ctx, typeNamespacedName, namespacerequest))).To(BeTrue())
+			ns := &corev1.Namespace{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: nsName}, ns)).To(Succeed())
+			Expect(ns.Labels).To(HaveKeyWithValue(guardianv1alpha1.LabelOrphaned, "true"))
+		})
+
+		It("should keep the finalizer and return the error when retaining the namespace fails", func() {
+			controllerReconciler := &NamespaceRequestReconciler{
+				Client:   k8sClient,
+				Scheme:   k8sClient.Scheme(),
+				Recorder: record.NewFakeRecorder(100),
+			}
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(k8sClient.Get(ctx, typeNamespacedName, namespacerequest)).To(Succeed())
+			nsName := namespacerequest.Status.NamespaceName
+
+			By("failing the namespace relabel")
+			Expect(k8sClient.Delete(ctx, namespacerequest)).To(Succeed())
+			failing := &NamespaceRequestReconciler{
+				Client:   &namespaceUpdateFailingClient{Client: k8sClient, err: errors.NewServiceUnavailable("apiserver overloaded")},
+				Scheme:   k8sClient.Scheme(),
+				Recorder: record.NewFakeRecorder(100),
+			}
+			_, err = failing.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).To(MatchError(ContainSubstring("apiserver overloaded")))
+			Expect(k8sClient.Get(ctx, typeNamespacedName, namespacerequest)).To(Succeed())
+			Expect(namespacerequest.Finalizers).To(ContainElement(guardianv1alpha1.FinalizerNamespaceRequest))
+			Expect(namespacerequest.Status.Phase).To(Equal(guardianv1alpha1.PhaseTerminating))
+			Expect(namespacerequest.Status.Message).To(ContainSubstring("retain namespace"))
+
+			By("retaining it on the next attempt")
+			_, err = controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(errors.IsNotFound(k8sClient.Get(ctx, typeNamespacedName, namespacerequest))).To(BeTrue())
+			ns := &corev1.Namespace{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: nsName}, ns)).To(Succeed())
+			Expect(ns.Labels).To(HaveKeyWithValue(guardianv1alpha1.LabelOrphaned, "true"))
+		})
+
+		It("should provision again when a retained namespace is requested again", func() {
+			controllerReconciler := &NamespaceRequestReconciler{
+				Client:   k8sClient,
+				Scheme:   k8sClient.Scheme(),
+				Recorder: record.NewFakeRecorder(100),
+			}
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(k8sClient.Get(ctx, typeNamespacedName, namespacerequest)).To(Succeed())
+			nsName := namespacerequest.Status.NamespaceName
+			spec := namespacerequest.Spec
+
+			By("deleting the request and retaining its namespace")
+			Expect(k8sClient.Delete(ctx, namespacerequest)).To(Succeed())
+			_, err = controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(errors.IsNotFound(k8sClient.Get(ctx, typeNamespacedName, namespacerequest))).To(BeTrue())
+			rqKey := types.NamespacedName{Namespace: nsName, Name: "guardian-rq-default"}
+			rq := &corev1.ResourceQuota{}
+			Expect(k8sClient.Get(ctx, rqKey, rq)).To(Succeed())
+			Expect(rq.Labels).NotTo(HaveKey(guardianv1alpha1.LabelManaged))
+
+			By("requesting the namespace again")
+			again := &guardianv1alpha1.NamespaceRequest{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:   resourceName,
+					Labels: map[string]string{guardianv1alpha1.LabelTenant: tenantName},
+				},
+				Spec: spec,
+			}
+			Expect(k8sClient.Create(ctx, again)).To(Succeed())
+			_, err = controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(k8sClient.Get(ctx, typeNamespacedName, namespacerequest)).To(Succeed())
+			Expect(namespacerequest.Status.Phase).To(Equal(guardianv1alpha1.PhaseProvisioned), namespacerequest.Status.Message)
+			Expect(namespacerequest.Status.NamespaceName).To(Equal(nsName))
+			ns := &corev1.Namespace{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: nsName}, ns)).To(Succeed())
+			Expect(ns.Labels).To(HaveKeyWithValue(guardianv1alpha1.LabelManaged, "true"))
+			Expect(ns.Labels).NotTo(HaveKey(guardianv1alpha1.LabelOrphaned))
+			Expect(k8sClient.Get(ctx, rqKey, rq)).To(Succeed())
+			Expect(rq.Labels).To(HaveKeyWithValue(guardianv1alpha1.LabelManaged, "true"))
+		})
+
+		It("should restore a deleted baseline object and count the drift", func() {
+			controllerReconciler := &NamespaceRequestReconciler{
+				Client:   k8sClient,
+				Scheme:   k8sClient.Scheme(),
+				Recorder: record.NewFakeRecorder(100),
+			}
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(k8sClient.Get(ctx, typeNamespacedName, namespacerequest)).To(Succeed())
+			before := namespacerequest.Status.DriftCorrected
+
+			By("deleting the default quota behind the controller's back")
+			key := types.NamespacedName{Namespace: namespacerequest.Status.NamespaceName, Name: "guardian-rq-default"}
+			rq := &corev1.ResourceQuota{}
+			Expect(k8sClient.Get(ctx, key, rq)).To(Succeed())
+			Expect(k8sClient.Delete(ctx, rq)).To(Succeed())
+
+			_, err = controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(k8sClient.Get(ctx, key, rq)).To(Succeed())
+			Expect(k8sClient.Get(ctx, typeNamespacedName, namespacerequest)).To(Succeed())
+			Expect(namespacerequest.Status.DriftCorrected).To(Equal(before + 1))
+		})
+
+		It("should not count a request spec change as drift", func() {
+			controllerReconciler := &NamespaceRequestReconciler{
+				Client:   k8sClient,
+				Scheme:   k8sClient.Scheme(),
+				Recorder: record.NewFakeRecorder(100),
+			}
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(k8sClient.Get(ctx, typeNamespacedName, namespacerequest)).To(Succeed())
+			Expect(namespacerequest.Status.AppliedGeneration).To(Equal(namespacerequest.Generation))
+			before := namespacerequest.Status.DriftCorrected
+
+			By("handing the namespace to another group")
+			namespacerequest.Spec.OwnerGroup = tenantName + ":ops"
+			Expect(k8sClient.Update(ctx, namespacerequest)).To(Succeed())
+
+			_, err = controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(k8sClient.Get(ctx, typeNamespacedName, namespacerequest)).To(Succeed())
+			Expect(namespacerequest.Status.AppliedGeneration).To(Equal(namespacerequest.Generation))
+			Expect(namespacerequest.Status.DriftCorrected).To(Equal(before))
+		})
+
+		It("should repair baseline objects whose managed label was removed instead of failing", func() {
+			Expect(k8sClient.Get(ctx, typeNamespacedName, namespacerequest)).To(Succeed())
+			viewers := guardianv1alpha1.ExtraBinding{Kind: guardianv1alpha1.ExtraBindingKindGroup, Name: tenantName + ":qa", Role: "view"}
+			namespacerequest.Spec.ExtraBindings = []guardianv1alpha1.ExtraBinding{viewers}
+			Expect(k8sClient.Update(ctx, namespacerequest)).To(Succeed())
+
+			controllerReconciler := &NamespaceRequestReconciler{
+				Client:   k8sClient,
+				Scheme:   k8sClient.Scheme(),
+				Recorder: record.NewFakeRecorder(100),
+			}
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(k8sClient.Get(ctx, typeNamespacedName, namespacerequest)).To(Succeed())
+			nsName := namespacerequest.Status.NamespaceName
+
+			By("stripping guardian.io/managed from the quota and the extra binding")
+			rq := &corev1.ResourceQuota{}
+			rqKey := types.NamespacedName{Namespace: nsName, Name: "guardian-rq-default"}
+			Expect(k8sClient.Get(ctx, rqKey, rq)).To(Succeed())
+			delete(rq.Labels, guardianv1alpha1.LabelManaged)
+			Expect(k8sClient.Update(ctx, rq)).To(Succeed())
+			rb := &rbacv1.RoleBinding{}
+			rbKey := types.NamespacedName{Namespace: nsName, Name: guardianv1alpha1.ExtraBindingName(viewers)}
+			Expect(k8sClient.Get(ctx, rbKey, rb)).To(Succeed())
+			delete(rb.Labels, guardianv1alpha1.LabelManaged)
+			Expect(k8sClient.Update(ctx, rb)).To(Succeed())
+
+			_, err = controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(k8sClient.Get(ctx, typeNamespacedName, namespacerequest)).To(Succeed())
+			Expect(namespacerequest.Status.Phase).To(Equal(guardianv1alpha1.PhaseProvisioned))
+			Expect(k8sClient.Get(ctx, rqKey, rq)).To(Succeed())
+			Expect(rq.Labels).To(HaveKeyWithValue(guardianv1alpha1.LabelManaged, "true"))
+			Expect(k8sClient.Get(ctx, rbKey, rb)).To(Succeed())
+			Expect(rb.Labels).To(HaveKeyWithValue(guardianv1alpha1.LabelManaged, "true"))
 		})
 	})
 })
+
+var _ = Describe("Baseline manifest templates", func() {
+	It("should substitute placeholders without changing the object structure", func() {
+		tmpl := "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: \"{tenant}-info\"\n  labels:\n    env: {env}\n" +
+			"data:\n  owner: {ownerGroup}\n  namespace: \"ns={namespace}\"\n"
+		obj, err := guardianv1alpha1.RenderManifest(tmpl, "tenant-a", "dev", "tenant-a-dev", "team: a\n# injected: true")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(obj.GetName()).To(Equal("tenant-a-info"))
+		Expect(obj.GetLabels()).To(Equal(map[string]string{"env": "dev"}))
+		data, _, _ := unstructured.NestedStringMap(obj.Object, "data")
+		Expect(data).To(Equal(map[string]string{"owner": "team: a\n# injected: true", "namespace": "ns=tenant-a-dev"}))
+	})
+})
+
+var _ = Describe("Baseline conditions", func() {
+	It("should leave every component unattempted when the baseline version cannot be resolved", func() {
+		nr := &guardianv1alpha1.NamespaceRequest{}
+		setBaselineConditions(nr, &BaselineError{guardianv1alpha1.CondBaselineVersionReady,
+			fmt.Errorf("%w: %w", errBaselineVersionUnresolved, errors.NewServiceUnavailable("apiserver overloaded"))}, nil)
+		version := meta.FindStatusCondition(nr.Status.Conditions, guardianv1alpha1.CondBaselineVersionReady)
+		Expect(version.Status).To(Equal(metav1.ConditionFalse))
+		Expect(version.Message).To(ContainSubstring("resolve baseline version"))
+		for _, c := range baselineConditions[:len(baselineConditions)-1] {
+			cond := meta.FindStatusCondition(nr.Status.Conditions, c)
+			Expect(cond.Status).To(Equal(metav1.ConditionUnknown), c)
+			Expect(cond.Reason).To(Equal("NotAttempted"), c)
+		}
+
+		By("a failed migration still reports the applied components")
+		setBaselineConditions(nr, &BaselineError{guardianv1alpha1.CondBaselineVersionReady, fmt.Errorf("migrate baseline")}, nil)
+		Expect(meta.IsStatusConditionTrue(nr.Status.Conditions, guardianv1alpha1.CondRBACReady)).To(BeTrue())
+		Expect(meta.IsStatusConditionFalse(nr.Status.Conditions, guardianv1alpha1.CondBaselineVersionReady)).To(BeTrue())
+	})
+})
+
+// terminatingNamespaceClient：读到的 namespace 都带 deletionTimestamp（envtest 里真删掉的 namespace 会一直 Terminating）
+type terminatingNamespaceClient struct {
+	client.Client
+}
+
+func (c *terminatingNamespaceClient) Get(ctx context.Context, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+	if ns, ok := obj.(*corev1.Namespace); ok {
+		ns.Name = key.Name
+		ns.DeletionTimestamp = &metav1.Time{Time: time.Now()}
+		return nil
+	}
+	return c.Client.Get(ctx, key, obj, opts...)
+}
+
+// flakyClient：err 非空时让 Create 失败，模拟 apiserver 的临时性错误
+type flakyClient struct {
+	client.Client
+	err error
+}
+
+func (c *flakyClient) Create(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
+	if c.err != nil {
+		return c.err
+	}
+	return c.Client.Create(ctx, obj, opts...)
+}
+
+// namespaceUpdateFailingClient：让 Namespace 的 Update 失败，模拟回收时摘标签出错
+type namespaceUpdateFailingClient struct {
+	client.Client
+	err error
+}
+
+func (c *namespaceUpdateFailingClient) Update(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+	if _, ok := obj.(*corev1.Namespace); ok {
+		return c.err
+	}
+	return c.Client.Update(ctx, obj, opts...)
+}
@@ -0,0 +1,216 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	guardiov1alpha1 "github.com/CATDOGME/namespace-guardian/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// 等待 namespace 删除完成的轮询间隔
+const reclaimRequeue = 5 * time.Second
+
+// selectReclaimPolicy：default -> byEnv[env] 按字段覆盖；未配置时 Retain
+func selectReclaimPolicy(t *guardiov1alpha1.Tenant, env string) guardiov1alpha1.ReclaimPolicy {
+	out := guardiov1alpha1.ReclaimPolicy{Policy: guardiov1alpha1.ReclaimPolicyRetain}
+	if t == nil || t.Spec.Reclaim == nil {
+		return out
+	}
+	merge := func(o guardiov1alpha1.ReclaimPolicy) {
+		if o.Policy != "" {
+			out.Policy = o.Policy
+		}
+		if o.RetainGracePeriod != nil {
+			out.RetainGracePeriod = o.RetainGracePeriod
+		}
+	}
+	merge(t.Spec.Reclaim.Default)
+	if o, ok := t.Spec.Reclaim.ByEnv[env]; ok {
+		merge(o)
+	}
+	return out
+}
+
+// reconcileDelete 处理 NamespaceRequest 删除：按 Tenant 的 reclaim 策略回收 namespace，完成后移除 finalizer
+func (r *NamespaceRequestReconciler) reconcileDelete(ctx context.Context, nr *guardiov1alpha1.NamespaceRequest) (ctrl.Result, error) {
+	l := log.FromContext(ctx)
+
+	if !controllerutil.ContainsFinalizer(nr, guardiov1alpha1.FinalizerNamespaceRequest) {
+		return ctrl.Result{}, nil
+	}
+
+	ns, err := r.ownedNamespace(ctx, nr)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	if ns != nil {
+		// Tenant 已不存在时按默认策略（Retain）处理
+		var t *guardiov1alpha1.Tenant
+		var tenant guardiov1alpha1.Tenant
+		if err := r.Get(ctx, types.NamespacedName{Name: nr.Spec.Tenant}, &tenant); err == nil {
+			t = &tenant
+		} else if !apierrors.IsNotFound(err) {
+			return ctrl.Result{}, err
+		}
+		// Tenant 被 suspend：紧急刹车同样拦住回收（删除/摘标签），保留 finalizer，解除后经 Watch 重新入队
+		if t != nil && t.Spec.Suspend {
+			l.Info("tenant suspended, hold namespace reclaim", "nsreq", nr.Name, "namespace", ns.Name)
+			return ctrl.Result{}, r.setStatusSuspended(ctx, nr, nr.Status.DeepCopy(), t.Name)
+		}
+
+		env := nr.Spec.Env
+		if env == "" {
+			env = "dev"
+		}
+		policy := selectReclaimPolicy(t, env)
+		// TTL 到期：namespace 跟随 request 一起删除
+		if requestExpired(nr) {
+			policy.Policy = guardiov1alpha1.ReclaimPolicyDelete
+		}
+
+		switch policy.Policy {
+		case guardiov1alpha1.ReclaimPolicyDelete:
+			if ns.DeletionTimestamp.IsZero() {
+				if err := r.Delete(ctx, ns); err != nil && !apierrors.IsNotFound(err) {
+					return ctrl.Result{}, err
+				}
+				l.Info("namespace reclaim started", "nsreq", nr.Name, "namespace", ns.Name)
+			}
+			msg := fmt.Sprintf("waiting for namespace %q to be deleted", ns.Name)
+			return ctrl.Result{RequeueAfter: reclaimRequeue}, r.setStatusTerminating(ctx, nr, msg)
+
+		default:
+			// 失败时保留 finalizer，status 记下原因后把错误交给 workqueue 退避重试
+			if err := retainNamespace(ctx, r.Client, ns, nr, policy); err != nil {
+				err = fmt.Errorf("retain namespace %q: %w", ns.Name, err)
+				if serr := r.setStatusTerminating(ctx, nr, err.Error()); serr != nil {
+					l.Error(serr, "update status failed", "nsreq", nr.Name)
+				}
+				return ctrl.Result{}, err
+			}
+			l.Info("namespace retained as orphaned", "nsreq", nr.Name, "namespace", ns.Name)
+		}
+	}
+
+	controllerutil.RemoveFinalizer(nr, guardiov1alpha1.FinalizerNamespaceRequest)
+	if err := r.Update(ctx, nr); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	return ctrl.Result{}, nil
+}
+
+// ownedNamespace 返回仍归属于该 request 且 managed 的 namespace；
+// 已被 Tenant Orphan 策略摘掉标签、或者被别的 request 认领的 namespace 不处理
+func (r *NamespaceRequestReconciler) ownedNamespace(ctx context.Context, nr *guardiov1alpha1.NamespaceRequest) (*corev1.Namespace, error) {
+	if nr.Status.NamespaceName == "" {
+		return nil, nil
+	}
+	var ns corev1.Namespace
+	if err := r.Get(ctx, types.NamespacedName{Name: nr.Status.NamespaceName}, &ns); err != nil {
+		return nil, client.IgnoreNotFound(err)
+	}
+	if ns.Labels[guardiov1alpha1.LabelManaged] != "true" || ns.Labels[guardiov1alpha1.LabelRequest] != nr.Name {
+		return nil, nil
+	}
+	return &ns, nil
+}
+
+// retainNamespace 摘掉 managed 标签并打上 orphaned；配置了 grace period 时记录回收时间，由 Tenant controller 到期删除
+func retainNamespace(ctx context.Context, c client.Client, ns *corev1.Namespace, nr *guardiov1alpha1.NamespaceRequest,
+	policy guardiov1alpha1.ReclaimPolicy) error {
+	if err := orphanNamespace(ctx, c, ns, []guardiov1alpha1.NamespaceRequest{*nr}); err != nil {
+		return err
+	}
+	if ns.Labels == nil {
+		ns.Labels = map[string]string{}
+	}
+	ns.Labels[guardiov1alpha1.LabelOrphaned] = "true"
+	delete(ns.Labels, guardiov1alpha1.LabelRequest)
+	if policy.RetainGracePeriod != nil && policy.RetainGracePeriod.Duration > 0 {
+		if ns.Annotations == nil {
+			ns.Annotations = map[string]string{}
+		}
+		ns.Annotations[guardiov1alpha1.AnnReclaimAfter] = time.Now().Add(policy.RetainGracePeriod.Duration).UTC().Format(time.RFC3339)
+	}
+	return c.Update(ctx, ns)
+}
+
+// reclaimOrphanedObjects：retain 时 orphanNamespace 摘掉了 baseline 对象的 managed 标签；同 tenant 重新申请这个 namespace 时，
+// 把带本 tenant 标签、没有 managed 标签的对象重新标成本 request 的，否则 EnsureBaseline 会把它们当成外来对象拒绝
+func reclaimOrphanedObjects(ctx context.Context, c client.Client, ns string, spec BaselineSpec) error {
+	lists := []client.ObjectList{&corev1.SecretList{}, &corev1.ConfigMapList{}}
+	for _, k := range prunedKinds {
+		lists = append(lists, k.newList())
+	}
+	for _, list := range lists {
+		if err := c.List(ctx, list, client.InNamespace(ns), client.MatchingLabels{guardiov1alpha1.LabelTenant: spec.Tenant}); err != nil {
+			return err
+		}
+		items, err := meta.ExtractList(list)
+		if err != nil {
+			return err
+		}
+		for _, item := range items {
+			if obj, ok := item.(client.Object); ok {
+				if err := reclaimObject(ctx, c, obj, spec); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	// manifests 的 kind 不固定，按 Tenant 当前的 manifests 渲染出的对象处理（渲染失败的由 EnsureBaseline 报告）
+	for _, m := range selectManifests(spec.TenantObj, spec.Env) {
+		desired, err := guardiov1alpha1.RenderManifest(m.Template, spec.Tenant, spec.Env, ns, spec.OwnerGroup)
+		if err != nil {
+			continue
+		}
+		obj := &unstructured.Unstructured{}
+		obj.SetGroupVersionKind(desired.GroupVersionKind())
+		err = c.Get(ctx, types.NamespacedName{Namespace: ns, Name: desired.GetName()}, obj)
+		if apierrors.IsNotFound(err) || meta.IsNoMatchError(err) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		if obj.GetLabels()[guardiov1alpha1.LabelTenant] != spec.Tenant {
+			continue
+		}
+		if err := reclaimObject(ctx, c, obj, spec); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// reclaimObject：orphaned 的 baseline 对象重新打上 managed 标签与本 request 的 hash
+func reclaimObject(ctx context.Context, c client.Client, obj client.Object, spec BaselineSpec) error {
+	labels := obj.GetLabels()
+	if labels[guardiov1alpha1.LabelManaged] == "true" {
+		return nil
+	}
+	labels[guardiov1alpha1.LabelManaged] = "true"
+	labels[guardiov1alpha1.LabelRequestHash] = guardiov1alpha1.ShortHash16(spec.RequestName)
+	obj.SetLabels(labels)
+	return client.IgnoreNotFound(c.Update(ctx, obj))
+}
+
+func (r *NamespaceRequestReconciler) setStatusTerminating(ctx context.Context, nr *guardiov1alpha1.NamespaceRequest, msg string) error {
+	old := nr.Status.DeepCopy()
+	nr.Status.Phase = guardiov1alpha1.PhaseTerminating
+	nr.Status.Reason = "Reclaiming"
+	nr.Status.Message = msg
+	return r.updateStatus(ctx, nr, old)
+}
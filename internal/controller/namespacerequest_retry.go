@@ -0,0 +1,111 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	guardiov1alpha1 "github.com/CATDOGME/namespace-guardian/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+// 临时性失败的指数退避：5s, 10s, 20s ... 封顶 10m
+const (
+	retryBaseDelay = 5 * time.Second
+	retryMaxDelay  = 10 * time.Minute
+)
+
+// permanentError：重试也不会成功的错误（Tenant 配置错误等），等 spec/Tenant 变化后再处理
+type permanentError struct{ err error }
+
+func (e *permanentError) Error() string { return e.err.Error() }
+func (e *permanentError) Unwrap() error { return e.err }
+
+func permanent(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &permanentError{err}
+}
+
+// isTransient：配置错误、namespace 冲突、被 apiserver 判为非法的对象、权限不足（RBAC / webhook 拒绝）属于终态失败，
+// 会自行消失的 Forbidden 除外（见 selfClearingForbidden）；
+// 其余（超时、冲突、限流、apiserver 不可用等）按退避重试
+func isTransient(err error) bool {
+	var pe *permanentError
+	switch {
+	case errors.As(err, &pe), errors.Is(err, errNamespaceConflict):
+		return false
+	case apierrors.IsForbidden(err):
+		return selfClearingForbidden(err)
+	case apierrors.IsInvalid(err), apierrors.IsBadRequest(err):
+		return false
+	}
+	return true
+}
+
+// selfClearingForbidden：不需要人介入、过一会儿自己会消失的 Forbidden 按临时性失败处理
+// - namespace 正在删除（NamespaceTerminating cause）：删完后重新创建
+// - ResourceQuota 用满：别的对象释放额度后即可成功
+// - apiserver 建议了 Retry-After
+func selfClearingForbidden(err error) bool {
+	if apierrors.HasStatusCause(err, corev1.NamespaceTerminatingCause) {
+		return true
+	}
+	if _, ok := apierrors.SuggestsClientDelay(err); ok {
+		return true
+	}
+	var status apierrors.APIStatus
+	return errors.As(err, &status) && strings.Contains(status.Status().Message, "exceeded quota")
+}
+
+// retryDelay：第 attempts 次失败后的等待时间
+func retryDelay(attempts int32) time.Duration {
+	d := retryBaseDelay
+	for i := int32(1); i < attempts && d < retryMaxDelay; i++ {
+		d *= 2
+	}
+	return min(d, retryMaxDelay)
+}
+
+// retryBackoff：Retrying 阶段还没到 nextRetryAt 时返回剩余等待时间；spec 变化后不再等待
+func retryBackoff(nr *guardiov1alpha1.NamespaceRequest) time.Duration {
+	rs := nr.Status.Retry
+	if nr.Status.Phase != guardiov1alpha1.PhaseRetrying || rs == nil || rs.NextRetryAt == nil ||
+		nr.Status.ObservedGeneration != nr.Generation {
+		return 0
+	}
+	return max(time.Until(rs.NextRetryAt.Time), 0)
+}
+
+// handleFailure：终态失败置 Failed 且不再 requeue；临时性失败置 Retrying，记录次数并按指数退避 requeue
+// 返回 nil error：退避由 RequeueAfter 控制，避免与 workqueue 自身的限速叠加
+func (r *NamespaceRequestReconciler) handleFailure(ctx context.Context, nr *guardiov1alpha1.NamespaceRequest,
+	old *guardiov1alpha1.NamespaceRequestStatus, reason string, err error) (ctrl.Result, error) {
+	if !isTransient(err) {
+		return ctrl.Result{}, r.setStatusFailed(ctx, nr, old, reason, err.Error())
+	}
+
+	attempts := int32(1)
+	if nr.Status.Retry != nil {
+		attempts = nr.Status.Retry.Attempts + 1
+	}
+	delay := retryDelay(attempts)
+	nr.Status.Retry = &guardiov1alpha1.RetryStatus{
+		Attempts:    attempts,
+		NextRetryAt: &metav1.Time{Time: time.Now().Add(delay)},
+		LastError:   err.Error(),
+	}
+	nr.Status.Phase = guardiov1alpha1.PhaseRetrying
+	nr.Status.Reason = reason
+	nr.Status.Message = fmt.Sprintf("attempt %d failed, retrying in %s: %v", attempts, delay, err)
+	if err := r.updateStatus(ctx, nr, old); err != nil {
+		return ctrl.Result{}, err
+	}
+	return ctrl.Result{RequeueAfter: delay}, nil
+}
@@ -0,0 +1,119 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	guardiov1alpha1 "github.com/CATDOGME/namespace-guardian/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// NamespaceRequest status reasons
+const (
+	// ReasonBaselineFailed：baseline 下发失败；Tenant rollout 遇到已放行的 request 报这个 reason 时暂停
+	ReasonBaselineFailed = "BaselineFailed"
+)
+
+// baselineConditions：EnsureBaseline 的执行顺序，与 BaselineError.Condition 对应
+var baselineConditions = []string{
+	guardiov1alpha1.CondRBACReady,
+	guardiov1alpha1.CondServiceAccountsReady,
+	guardiov1alpha1.CondPropagationReady,
+	guardiov1alpha1.CondQuotaReady,
+	guardiov1alpha1.CondLimitRangeReady,
+	guardiov1alpha1.CondNetworkPolicyReady,
+	guardiov1alpha1.CondManifestsReady,
+	guardiov1alpha1.CondBaselineVersionReady,
+}
+
+// negativeConditions：True 表示异常的 condition（事件类型反过来）
+var negativeConditions = map[string]bool{
+	guardiov1alpha1.CondExpiring: true,
+}
+
+func setCondition(nr *guardiov1alpha1.NamespaceRequest, condType string, status metav1.ConditionStatus, reason, msg string) {
+	meta.SetStatusCondition(&nr.Status.Conditions, metav1.Condition{
+		Type:               condType,
+		Status:             status,
+		Reason:             reason,
+		Message:            msg,
+		ObservedGeneration: nr.Generation,
+	})
+}
+
+// setBaselineConditions：失败组件之前的置 True，失败组件置 False，之后的未执行置 Unknown
+// （BaselineVersionReady 既是第 0 步的版本解析也是最后的迁移，解析失败时其余组件都算未执行）
+// server-side apply 冲突：被强制接管的组件仍为 True 但 reason 为 ConflictsForced；策略为 Report 的冲突 reason 为 FieldConflict
+func setBaselineConditions(nr *guardiov1alpha1.NamespaceRequest, err error, conflicts map[string][]string) {
+	failed := ""
+	var be *BaselineError
+	if errors.As(err, &be) {
+		failed = be.Condition
+	}
+
+	// 版本解析失败时没有组件执行过，全部置 Unknown
+	reached := failed == guardiov1alpha1.CondBaselineVersionReady && errors.Is(err, errBaselineVersionUnresolved)
+	for _, c := range baselineConditions {
+		switch {
+		case c == failed:
+			reason := "ApplyFailed"
+			if apierrors.IsConflict(be.Err) {
+				reason = "FieldConflict"
+			}
+			setCondition(nr, c, metav1.ConditionFalse, reason, be.Err.Error())
+			reached = true
+		case reached:
+			setCondition(nr, c, metav1.ConditionUnknown, "NotAttempted", fmt.Sprintf("skipped because %s failed", failed))
+		case len(conflicts[c]) > 0:
+			setCondition(nr, c, metav1.ConditionTrue, "ConflictsForced",
+				"took over fields owned by other managers: "+strings.Join(conflicts[c], "; "))
+		default:
+			setCondition(nr, c, metav1.ConditionTrue, "Applied", "baseline objects are up to date")
+		}
+	}
+}
+
+// updateStatus 写回 status（无变化时跳过），并为 phase 与每个 condition 的变化记录 Event
+func (r *NamespaceRequestReconciler) updateStatus(ctx context.Context, nr *guardiov1alpha1.NamespaceRequest,
+	old *guardiov1alpha1.NamespaceRequestStatus) error {
+	nr.Status.ObservedGeneration = nr.Generation
+	if equality.Semantic.DeepEqual(old, &nr.Status) {
+		return nil
+	}
+	if err := r.Status().Update(ctx, nr); err != nil {
+		return err
+	}
+	r.recordTransitions(nr, old)
+	return nil
+}
+
+func (r *NamespaceRequestReconciler) recordTransitions(nr *guardiov1alpha1.NamespaceRequest, old *guardiov1alpha1.NamespaceRequestStatus) {
+	if old.Phase != nr.Status.Phase {
+		eventType := corev1.EventTypeNormal
+		if nr.Status.Phase == guardiov1alpha1.PhaseFailed || nr.Status.Phase == guardiov1alpha1.PhaseRetrying {
+			eventType = corev1.EventTypeWarning
+		}
+		reason := nr.Status.Reason
+		if reason == "" {
+			reason = string(nr.Status.Phase)
+		}
+		r.Recorder.Eventf(nr, eventType, reason, "phase %q -> %q: %s", old.Phase, nr.Status.Phase, nr.Status.Message)
+	}
+
+	for _, c := range nr.Status.Conditions {
+		if prev := meta.FindStatusCondition(old.Conditions, c.Type); prev != nil && prev.Status == c.Status {
+			continue
+		}
+		eventType := corev1.EventTypeNormal
+		if c.Status == metav1.ConditionFalse != negativeConditions[c.Type] {
+			eventType = corev1.EventTypeWarning
+		}
+		r.Recorder.Eventf(nr, eventType, c.Reason, "%s=%s: %s", c.Type, c.Status, c.Message)
+	}
+}
@@ -0,0 +1,27 @@
+package controller
+
+import (
+	guardiov1alpha1 "github.com/CATDOGME/namespace-guardian/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// status.ownerHistory 最多保留的记录数
+const maxOwnerHistory = 10
+
+// recordOwnerTransfer：baseline 已按新 ownerGroup 重新绑定后，把移交写入 status 历史并记录 Event
+// 发起/接受人来自 webhook 写入的注解（按 req.UserInfo，不能伪造）
+func (r *NamespaceRequestReconciler) recordOwnerTransfer(nr *guardiov1alpha1.NamespaceRequest, from, to string) {
+	nr.Status.OwnerHistory = append(nr.Status.OwnerHistory, guardiov1alpha1.OwnerTransfer{
+		From:        from,
+		To:          to,
+		RequestedBy: nr.Annotations[guardiov1alpha1.AnnTransferRequestedBy],
+		AcceptedBy:  nr.Annotations[guardiov1alpha1.AnnTransferAcceptedBy],
+		Time:        metav1.Now(),
+	})
+	if n := len(nr.Status.OwnerHistory); n > maxOwnerHistory {
+		nr.Status.OwnerHistory = nr.Status.OwnerHistory[n-maxOwnerHistory:]
+	}
+	r.Recorder.Eventf(nr, corev1.EventTypeNormal, "OwnerTransferred",
+		"ownerGroup %q -> %q, guardian-owner-edit rebound", from, to)
+}
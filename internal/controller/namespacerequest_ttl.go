@@ -0,0 +1,77 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	guardiov1alpha1 "github.com/CATDOGME/namespace-guardian/api/v1alpha1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// requestExpired：status.expiresAt 已过，删除时无视 reclaim 策略直接删 namespace
+func requestExpired(nr *guardiov1alpha1.NamespaceRequest) bool {
+	return nr.Status.ExpiresAt != nil && !time.Now().Before(nr.Status.ExpiresAt.Time)
+}
+
+// reconcileExpiry 维护 status.expiresAt 与 Expiring condition，到期时删除 NamespaceRequest（finalizer 负责删 namespace）
+// 返回距下一个时间点（告警/到期）的等待时间；expired=true 时调用方直接结束本次 reconcile
+func (r *NamespaceRequestReconciler) reconcileExpiry(ctx context.Context, nr *guardiov1alpha1.NamespaceRequest,
+	t *guardiov1alpha1.Tenant, old *guardiov1alpha1.NamespaceRequestStatus) (time.Duration, bool, error) {
+	nr.Status.ExpiresAt = guardiov1alpha1.RequestExpiresAt(nr)
+	if nr.Status.ExpiresAt == nil {
+		meta.RemoveStatusCondition(&nr.Status.Conditions, guardiov1alpha1.CondExpiring)
+		return 0, false, nil
+	}
+
+	expiresAt := nr.Status.ExpiresAt.Time
+	left := time.Until(expiresAt)
+	if left <= 0 {
+		msg := fmt.Sprintf("ttl expired at %s, deleting request and namespace", expiresAt.Format(time.RFC3339))
+		setCondition(nr, guardiov1alpha1.CondExpiring, metav1.ConditionTrue, "Expired", msg)
+		nr.Status.Reason = "Expired"
+		nr.Status.Message = msg
+		if err := r.updateStatus(ctx, nr, old); err != nil {
+			return 0, true, err
+		}
+		if err := r.Delete(ctx, nr); err != nil && !apierrors.IsNotFound(err) {
+			return 0, true, err
+		}
+		log.FromContext(ctx).Info("namespace request expired", "nsreq", nr.Name, "expiresAt", expiresAt)
+		return 0, true, nil
+	}
+
+	// Tenant 不存在时按默认提前量告警
+	warn := guardiov1alpha1.DefaultTTLWarnBefore
+	if t != nil {
+		warn = guardiov1alpha1.TTLWarnBefore(&t.Spec)
+	}
+	if left <= warn {
+		setCondition(nr, guardiov1alpha1.CondExpiring, metav1.ConditionTrue, "ExpiringSoon",
+			fmt.Sprintf("expires at %s; annotate %s=<duration> to extend",
+				expiresAt.Format(time.RFC3339), guardiov1alpha1.AnnExtendTTL))
+		return left, false, nil
+	}
+	setCondition(nr, guardiov1alpha1.CondExpiring, metav1.ConditionFalse, "NotExpiring",
+		fmt.Sprintf("expires at %s", expiresAt.Format(time.RFC3339)))
+	return left - warn, false, nil
+}
+
+// setStatusTenantFailed：Tenant 不存在/无效时终态失败，但 TTL 照常计时并到期回收（t 为 nil 表示不存在；suspend 时不回收）
+func (r *NamespaceRequestReconciler) setStatusTenantFailed(ctx context.Context, nr *guardiov1alpha1.NamespaceRequest,
+	t *guardiov1alpha1.Tenant, old *guardiov1alpha1.NamespaceRequestStatus, reason, msg string) (ctrl.Result, error) {
+	var ttlRequeue time.Duration
+	if t == nil || !t.Spec.Suspend {
+		var expired bool
+		var err error
+		ttlRequeue, expired, err = r.reconcileExpiry(ctx, nr, t, old)
+		if expired || err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+	return ctrl.Result{RequeueAfter: ttlRequeue}, r.setStatusFailed(ctx, nr, old, reason, msg)
+}
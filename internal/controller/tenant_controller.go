@@ -18,15 +18,42 @@ package controller
 
 import (
 	"context"
+	"fmt"
+	"strings"
+	"time"
 
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	guardianv1alpha1 "github.com/CATDOGME/namespace-guardian/api/v1alpha1"
 )
 
+// Tenant condition reasons
+const (
+	ReasonSpecValid          = "SpecValid"
+	ReasonNoAllowedGroups    = "NoAllowedGroups"
+	ReasonInvalidNamePattern = "InvalidNamespaceNamePattern"
+	ReasonInvalidBaseline    = "InvalidBaseline"
+
+	ReasonAllApplied        = "AllApplied"
+	ReasonNoNamespaces      = "NoNamespaces"
+	ReasonBaselinePending   = "BaselinePending"
+	ReasonBaselineFailures  = "BaselineFailures"
+	ReasonTenantSuspended   = "TenantSuspended"
+	ReasonRolloutInProgress = "RolloutInProgress"
+)
+
 // TenantReconciler reconciles a Tenant object
 type TenantReconciler struct {
 	client.Client
@@ -36,28 +63,239 @@ type TenantReconciler struct {
 // +kubebuilder:rbac:groups=guardian.guardian.io,resources=tenants,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=guardian.guardian.io,resources=tenants/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=guardian.guardian.io,resources=tenants/finalizers,verbs=update
+// +kubebuilder:rbac:groups=guardian.guardian.io,resources=namespacerequests,verbs=get;list;watch;delete
+// +kubebuilder:rbac:groups="",resources=namespaces,verbs=get;list;watch;update;delete
+// +kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=rolebindings,verbs=get;list;update
+// +kubebuilder:rbac:groups="",resources=resourcequotas;limitranges,verbs=get;list;update
+// +kubebuilder:rbac:groups=networking.k8s.io,resources=networkpolicies,verbs=get;list;update
 
-// Reconcile is part of the main kubernetes reconciliation loop which aims to
-// move the current state of the cluster closer to the desired state.
-// TODO(user): Modify the Reconcile function to compare the state specified by
-// the Tenant object against the actual cluster state, and then
-// perform operations to make the cluster state reflect the state specified by
-// the user.
-//
-// For more details, check Reconcile and its Result here:
-// - https://pkg.go.dev/sigs.k8s.io/controller-runtime@v0.22.4/pkg/reconcile
+// Reconcile 汇总 Tenant 的状态：
+// - Valid：spec 是否可用（allowedGroups / namespaceNamePattern / baseline 数值）
+// - ManagedNamespaces：带 guardian.io/tenant=<name> 且 managed 的 namespace 数量
+// - BaselineVersions：这些 namespace 各处于哪个 baseline 版本
+// - BaselineApplied：该 tenant 下所有 NamespaceRequest 是否都已下发 baseline（含 rollout 是否完成）
+// - Rollout：Tenant 变化按阶段/速率推进到已有 namespace 的进度
 func (r *TenantReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
-	_ = logf.FromContext(ctx)
+	l := logf.FromContext(ctx)
+
+	var t guardianv1alpha1.Tenant
+	if err := r.Get(ctx, req.NamespacedName, &t); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	// 删除：按 deletionPolicy 处理
+	if !t.DeletionTimestamp.IsZero() {
+		return r.reconcileDelete(ctx, &t)
+	}
+
+	if controllerutil.AddFinalizer(&t, guardianv1alpha1.FinalizerTenant) {
+		if err := r.Update(ctx, &t); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
+	// retain 到期的 orphaned namespace 回收（suspend 时暂停，解除后 generation 变化重新入队）
+	var requeueAfter time.Duration
+	if !t.Spec.Suspend {
+		var err error
+		if requeueAfter, err = r.reclaimExpiredNamespaces(ctx, &t); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
 
-	// TODO(user): your logic here
+	newStatus := t.Status.DeepCopy()
+
+	// 1) spec 校验
+	meta.SetStatusCondition(&newStatus.Conditions, validCondition(&t))
+
+	// 2) 统计 managed namespace
+	var nsList corev1.NamespaceList
+	if err := r.List(ctx, &nsList, client.MatchingLabels{
+		guardianv1alpha1.LabelTenant:  t.Name,
+		guardianv1alpha1.LabelManaged: "true",
+	}); err != nil {
+		return ctrl.Result{}, err
+	}
+	newStatus.ManagedNamespaces = int32(len(nsList.Items))
+	newStatus.BaselineVersions = countBaselineVersions(nsList.Items)
+
+	// 3) baseline 汇总：以 NamespaceRequest 的 phase 为准
+	var reqList guardianv1alpha1.NamespaceRequestList
+	if err := r.List(ctx, &reqList, client.MatchingLabels{
+		guardianv1alpha1.LabelTenant: t.Name,
+	}); err != nil {
+		return ctrl.Result{}, err
+	}
+	// 4) rollout：按阶段/速率放行已下发过旧 generation 的 namespace
+	rolloutStatus, rolloutRequeue := planRollout(&t, reqList.Items, time.Now())
+	newStatus.Rollout = rolloutStatus
+	if rolloutRequeue > 0 && (requeueAfter == 0 || rolloutRequeue < requeueAfter) {
+		requeueAfter = rolloutRequeue
+	}
+	meta.SetStatusCondition(&newStatus.Conditions, baselineAppliedCondition(&t, reqList.Items, rolloutStatus))
+
+	newStatus.ObservedGeneration = t.Generation
+
+	if equality.Semantic.DeepEqual(&t.Status, newStatus) {
+		return ctrl.Result{RequeueAfter: requeueAfter}, nil
+	}
+	t.Status = *newStatus
+	if err := r.Status().Update(ctx, &t); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	l.Info("tenant status updated", "tenant", t.Name, "managedNamespaces", newStatus.ManagedNamespaces)
+	return ctrl.Result{RequeueAfter: requeueAfter}, nil
+}
+
+// reclaimExpiredNamespaces 删除 guardian.io/reclaim-after 已到期的 orphaned namespace，
+// 返回距离下一个到期的时间（0 表示没有待回收的）
+func (r *TenantReconciler) reclaimExpiredNamespaces(ctx context.Context, t *guardianv1alpha1.Tenant) (time.Duration, error) {
+	var nsList corev1.NamespaceList
+	if err := r.List(ctx, &nsList, client.MatchingLabels{
+		guardianv1alpha1.LabelTenant:   t.Name,
+		guardianv1alpha1.LabelOrphaned: "true",
+	}); err != nil {
+		return 0, err
+	}
+
+	var next time.Duration
+	now := time.Now()
+	for i := range nsList.Items {
+		ns := &nsList.Items[i]
+		raw, ok := ns.Annotations[guardianv1alpha1.AnnReclaimAfter]
+		if !ok || !ns.DeletionTimestamp.IsZero() {
+			continue
+		}
+		deadline, err := time.Parse(time.RFC3339, raw)
+		if err != nil {
+			logf.FromContext(ctx).Error(err, "invalid reclaim-after annotation", "namespace", ns.Name)
+			continue
+		}
+		if wait := deadline.Sub(now); wait > 0 {
+			if next == 0 || wait < next {
+				next = wait
+			}
+			continue
+		}
+		if err := r.Delete(ctx, ns); client.IgnoreNotFound(err) != nil {
+			return 0, err
+		}
+		logf.FromContext(ctx).Info("orphaned namespace reclaimed", "tenant", t.Name, "namespace", ns.Name)
+	}
+	return next, nil
+}
+
+// countBaselineVersions：按 namespace 上记录的 baseline 版本计数（按版本从旧到新排列，迁移进度一目了然）
+func countBaselineVersions(namespaces []corev1.Namespace) []guardianv1alpha1.BaselineVersionCount {
+	counts := map[string]int32{}
+	for i := range namespaces {
+		counts[guardianv1alpha1.AppliedBaselineVersion(namespaces[i].Annotations)]++
+	}
+	var out []guardianv1alpha1.BaselineVersionCount
+	for _, v := range guardianv1alpha1.BaselineVersions {
+		if n := counts[v]; n > 0 {
+			out = append(out, guardianv1alpha1.BaselineVersionCount{Version: v, Namespaces: n})
+		}
+	}
+	return out
+}
+
+func validCondition(t *guardianv1alpha1.Tenant) metav1.Condition {
+	cond := metav1.Condition{
+		Type:               guardianv1alpha1.CondValid,
+		Status:             metav1.ConditionTrue,
+		Reason:             ReasonSpecValid,
+		Message:            "tenant spec is valid",
+		ObservedGeneration: t.Generation,
+	}
+	errs := guardianv1alpha1.ValidateTenantSpec(&t.Spec, field.NewPath("spec"))
+	if len(errs) == 0 {
+		return cond
+	}
+
+	cond.Status = metav1.ConditionFalse
+	cond.Message = errs.ToAggregate().Error()
+	// reason 以第一个错误所在字段归类
+	switch f := errs[0].Field; {
+	case strings.HasPrefix(f, "spec.allowedGroups"):
+		cond.Reason = ReasonNoAllowedGroups
+	case strings.HasPrefix(f, "spec.namespaceNamePattern"):
+		cond.Reason = ReasonInvalidNamePattern
+	default:
+		cond.Reason = ReasonInvalidBaseline
+	}
+	return cond
+}
+
+func baselineAppliedCondition(t *guardianv1alpha1.Tenant, reqs []guardianv1alpha1.NamespaceRequest,
+	rollout *guardianv1alpha1.TenantRolloutStatus) metav1.Condition {
+	cond := metav1.Condition{
+		Type:               guardianv1alpha1.CondBaselineApplied,
+		ObservedGeneration: t.Generation,
+	}
+	if len(reqs) == 0 {
+		cond.Status = metav1.ConditionUnknown
+		cond.Reason = ReasonNoNamespaces
+		cond.Message = "tenant has no namespace requests"
+		return cond
+	}
+
+	if t.Spec.Suspend {
+		cond.Status = metav1.ConditionFalse
+		cond.Reason = ReasonTenantSuspended
+		cond.Message = fmt.Sprintf("tenant is suspended, baseline reconcile paused for %d requests", len(reqs))
+		return cond
+	}
+
+	var provisioned, failed int
+	var failedNames []string
+	for i := range reqs {
+		switch reqs[i].Status.Phase {
+		case guardianv1alpha1.PhaseProvisioned:
+			provisioned++
+		case guardianv1alpha1.PhaseFailed:
+			failed++
+			failedNames = append(failedNames, reqs[i].Name)
+		}
+	}
+
+	switch {
+	case failed > 0:
+		cond.Status = metav1.ConditionFalse
+		cond.Reason = ReasonBaselineFailures
+		cond.Message = fmt.Sprintf("%d/%d requests failed: %s", failed, len(reqs), strings.Join(failedNames, ","))
+	case provisioned < len(reqs):
+		cond.Status = metav1.ConditionFalse
+		cond.Reason = ReasonBaselinePending
+		cond.Message = fmt.Sprintf("%d/%d requests provisioned", provisioned, len(reqs))
+	case rollout != nil && rollout.Phase != guardianv1alpha1.RolloutComplete:
+		cond.Status = metav1.ConditionFalse
+		cond.Reason = ReasonRolloutInProgress
+		cond.Message = fmt.Sprintf("rollout %s: %s", rollout.Phase, rollout.Message)
+	default:
+		cond.Status = metav1.ConditionTrue
+		cond.Reason = ReasonAllApplied
+		cond.Message = fmt.Sprintf("baseline applied to all %d requests", len(reqs))
+	}
+	return cond
+}
 
-	return ctrl.Result{}, nil
+// tenantFromLabels 把带 guardian.io/tenant 标签的对象映射回 Tenant
+func tenantFromLabels(_ context.Context, obj client.Object) []reconcile.Request {
+	tenant := obj.GetLabels()[guardianv1alpha1.LabelTenant]
+	if tenant == "" {
+		return nil
+	}
+	return []reconcile.Request{{NamespacedName: types.NamespacedName{Name: tenant}}}
 }
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *TenantReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&guardianv1alpha1.Tenant{}).
+		Watches(&guardianv1alpha1.NamespaceRequest{}, handler.EnqueueRequestsFromMapFunc(tenantFromLabels)).
+		Watches(&corev1.Namespace{}, handler.EnqueueRequestsFromMapFunc(tenantFromLabels)).
 		Named("tenant").
 		Complete(r)
 }
@@ -18,11 +18,19 @@ package controller
 
 import (
 	"context"
+	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -37,8 +45,7 @@ var _ = Describe("Tenant Controller", func() {
 		ctx := context.Background()
 
 		typeNamespacedName := types.NamespacedName{
-			Name:      resourceName,
-			Namespace: "default", // TODO(user):Modify as needed
+			Name: resourceName,
 		}
 		tenant := &guardianv1alpha1.Tenant{}
 
@@ -48,23 +55,35 @@ var _ = Describe("Tenant Controller", func() {
 			if err != nil && errors.IsNotFound(err) {
 				resource := &guardianv1alpha1.Tenant{
 					ObjectMeta: metav1.ObjectMeta{
-						Name:      resourceName,
-						Namespace: "default",
+						Name: resourceName,
+					},
+					Spec: guardianv1alpha1.TenantSpec{
+						AllowedGroups: []string{"test-resource:dev"},
 					},
-					// TODO(user): Specify other spec details if needed.
 				}
 				Expect(k8sClient.Create(ctx, resource)).To(Succeed())
 			}
 		})
 
 		AfterEach(func() {
-			// TODO(user): Cleanup logic after each test, like removing the resource instance.
 			resource := &guardianv1alpha1.Tenant{}
 			err := k8sClient.Get(ctx, typeNamespacedName, resource)
+			if errors.IsNotFound(err) {
+				return
+			}
 			Expect(err).NotTo(HaveOccurred())
 
 			By("Cleanup the specific resource instance Tenant")
 			Expect(k8sClient.Delete(ctx, resource)).To(Succeed())
+
+			By("Reconciling once more so the finalizer is released")
+			controllerReconciler := &TenantReconciler{
+				Client: k8sClient,
+				Scheme: k8sClient.Scheme(),
+			}
+			_, err = controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(errors.IsNotFound(k8sClient.Get(ctx, typeNamespacedName, resource))).To(BeTrue())
 		})
 		It("should successfully reconcile the resource", func() {
 			By("Reconciling the created resource")
@@ -77,8 +96,396 @@ var _ = Describe("Tenant Controller", func() {
 				NamespacedName: typeNamespacedName,
 			})
 			Expect(err).NotTo(HaveOccurred())
-			// TODO(user): Add more specific assertions depending on your controller's reconciliation logic.
-			// Example: If you expect a certain status condition after reconciliation, verify it here.
+
+			By("checking the tenant status was filled")
+			Expect(k8sClient.Get(ctx, typeNamespacedName, tenant)).To(Succeed())
+			Expect(tenant.Status.ObservedGeneration).To(Equal(tenant.Generation))
+			valid := meta.FindStatusCondition(tenant.Status.Conditions, guardianv1alpha1.CondValid)
+			Expect(valid).NotTo(BeNil())
+			Expect(valid.Status).To(Equal(metav1.ConditionTrue))
+			applied := meta.FindStatusCondition(tenant.Status.Conditions, guardianv1alpha1.CondBaselineApplied)
+			Expect(applied).NotTo(BeNil())
+			Expect(applied.Reason).To(Equal(ReasonNoNamespaces))
+		})
+
+		It("should mark an invalid namespaceNamePattern as not valid", func() {
+			By("setting a pattern that does not compile")
+			Expect(k8sClient.Get(ctx, typeNamespacedName, tenant)).To(Succeed())
+			tenant.Spec.NamespaceNamePattern = "^(unclosed"
+			Expect(k8sClient.Update(ctx, tenant)).To(Succeed())
+
+			controllerReconciler := &TenantReconciler{
+				Client: k8sClient,
+				Scheme: k8sClient.Scheme(),
+			}
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{
+				NamespacedName: typeNamespacedName,
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(k8sClient.Get(ctx, typeNamespacedName, tenant)).To(Succeed())
+			valid := meta.FindStatusCondition(tenant.Status.Conditions, guardianv1alpha1.CondValid)
+			Expect(valid).NotTo(BeNil())
+			Expect(valid.Status).To(Equal(metav1.ConditionFalse))
+			Expect(valid.Reason).To(Equal(ReasonInvalidNamePattern))
+		})
+
+		It("should block deletion while namespace requests exist", func() {
+			controllerReconciler := &TenantReconciler{
+				Client: k8sClient,
+				Scheme: k8sClient.Scheme(),
+			}
+			By("adding the finalizer")
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(k8sClient.Get(ctx, typeNamespacedName, tenant)).To(Succeed())
+			Expect(tenant.Finalizers).To(ContainElement(guardianv1alpha1.FinalizerTenant))
+
+			By("creating a request that belongs to the tenant")
+			nr := &guardianv1alpha1.NamespaceRequest{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:   "test-resource-blocker",
+					Labels: map[string]string{guardianv1alpha1.LabelTenant: resourceName},
+				},
+				Spec: guardianv1alpha1.NamespaceRequestSpec{
+					Tenant:     resourceName,
+					Env:        "dev",
+					OwnerGroup: "test-resource:dev",
+				},
+			}
+			Expect(k8sClient.Create(ctx, nr)).To(Succeed())
+
+			By("deleting the tenant")
+			Expect(k8sClient.Delete(ctx, tenant)).To(Succeed())
+			_, err = controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(k8sClient.Get(ctx, typeNamespacedName, tenant)).To(Succeed())
+			deleting := meta.FindStatusCondition(tenant.Status.Conditions, guardianv1alpha1.CondDeleting)
+			Expect(deleting).NotTo(BeNil())
+			Expect(deleting.Reason).To(Equal(ReasonDeletionBlocked))
+
+			By("removing the request unblocks deletion")
+			Expect(k8sClient.Delete(ctx, nr)).To(Succeed())
+		})
+
+		// managedNamespace：建一个属于本 tenant 的 managed namespace 和 request（不经 NamespaceRequest controller）
+		managedNamespace := func(name string) (*corev1.Namespace, *guardianv1alpha1.NamespaceRequest) {
+			labels := map[string]string{guardianv1alpha1.LabelTenant: resourceName, guardianv1alpha1.LabelManaged: "true"}
+			ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels}}
+			Expect(k8sClient.Create(ctx, ns)).To(Succeed())
+			nr := &guardianv1alpha1.NamespaceRequest{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:   name,
+					Labels: map[string]string{guardianv1alpha1.LabelTenant: resourceName},
+				},
+				Spec: guardianv1alpha1.NamespaceRequestSpec{Tenant: resourceName, Env: "dev", OwnerGroup: "test-resource:dev"},
+			}
+			Expect(k8sClient.Create(ctx, nr)).To(Succeed())
+			nr.Status.NamespaceName = name
+			Expect(k8sClient.Status().Update(ctx, nr)).To(Succeed())
+			return ns, nr
+		}
+		deleteTenant := func(policy string) *TenantReconciler {
+			controllerReconciler := &TenantReconciler{Client: k8sClient, Scheme: k8sClient.Scheme()}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, tenant)).To(Succeed())
+			tenant.Spec.DeletionPolicy = policy
+			Expect(k8sClient.Update(ctx, tenant)).To(Succeed())
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(k8sClient.Get(ctx, typeNamespacedName, tenant)).To(Succeed())
+			Expect(k8sClient.Delete(ctx, tenant)).To(Succeed())
+			return controllerReconciler
+		}
+
+		It("should leave namespaces and their baseline objects unmanaged with deletionPolicy=Orphan", func() {
+			ns, nr := managedNamespace("test-resource-orphan")
+			baseline := map[string]string{guardianv1alpha1.LabelManaged: "true"}
+			rb := &rbacv1.RoleBinding{
+				ObjectMeta: metav1.ObjectMeta{Name: "guardian-owner-edit", Namespace: ns.Name, Labels: baseline},
+				RoleRef:    rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "ClusterRole", Name: "edit"},
+				Subjects:   []rbacv1.Subject{{APIGroup: rbacv1.GroupName, Kind: rbacv1.GroupKind, Name: "test-resource:dev"}},
+			}
+			Expect(k8sClient.Create(ctx, rb)).To(Succeed())
+			rq := &corev1.ResourceQuota{ObjectMeta: metav1.ObjectMeta{Name: "guardian-rq-default", Namespace: ns.Name, Labels: baseline}}
+			Expect(k8sClient.Create(ctx, rq)).To(Succeed())
+			sa := &corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: "deployer", Namespace: ns.Name, Labels: baseline}}
+			Expect(k8sClient.Create(ctx, sa)).To(Succeed())
+			cm := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "ca-bundle", Namespace: ns.Name, Labels: baseline}}
+			Expect(k8sClient.Create(ctx, cm)).To(Succeed())
+			maxUnavailable := intstr.FromInt32(1)
+			pdb := &policyv1.PodDisruptionBudget{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-pdb", Namespace: ns.Name, Labels: baseline},
+				Spec:       policyv1.PodDisruptionBudgetSpec{MaxUnavailable: &maxUnavailable},
+			}
+			Expect(k8sClient.Create(ctx, pdb)).To(Succeed())
+			nr.Status.Manifests = []guardianv1alpha1.ManifestStatus{{
+				Name: "pdb", APIVersion: "policy/v1", Kind: "PodDisruptionBudget", ObjectName: "test-pdb", Applied: true,
+			}}
+			Expect(k8sClient.Status().Update(ctx, nr)).To(Succeed())
+
+			controllerReconciler := deleteTenant(guardianv1alpha1.DeletionPolicyOrphan)
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(errors.IsNotFound(k8sClient.Get(ctx, typeNamespacedName, tenant))).To(BeTrue())
+			Expect(errors.IsNotFound(k8sClient.Get(ctx, client.ObjectKeyFromObject(nr), nr))).To(BeTrue())
+			for _, obj := range []client.Object{ns, rb, rq, sa, cm, pdb} {
+				Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(obj), obj)).To(Succeed())
+				Expect(obj.GetLabels()).NotTo(HaveKey(guardianv1alpha1.LabelManaged), obj.GetName())
+			}
+		})
+
+		It("should pause TTL reclaim and deletion while suspended", func() {
+			orphaned := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{
+				Name:        "test-resource-expired",
+				Labels:      map[string]string{guardianv1alpha1.LabelTenant: resourceName, guardianv1alpha1.LabelOrphaned: "true"},
+				Annotations: map[string]string{guardianv1alpha1.AnnReclaimAfter: time.Now().Add(-time.Hour).Format(time.RFC3339)},
+			}}
+			Expect(k8sClient.Create(ctx, orphaned)).To(Succeed())
+			DeferCleanup(func() {
+				Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(orphaned), orphaned)).To(Succeed())
+				orphaned.Labels = nil
+				Expect(k8sClient.Update(ctx, orphaned)).To(Succeed())
+			})
+			ns, _ := managedNamespace("test-resource-suspended")
+
+			By("suspending the tenant")
+			controllerReconciler := &TenantReconciler{Client: k8sClient, Scheme: k8sClient.Scheme()}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, tenant)).To(Succeed())
+			tenant.Spec.Suspend = true
+			tenant.Spec.DeletionPolicy = guardianv1alpha1.DeletionPolicyOrphan
+			Expect(k8sClient.Update(ctx, tenant)).To(Succeed())
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(orphaned), orphaned)).To(Succeed())
+			Expect(orphaned.DeletionTimestamp).To(BeNil())
+
+			By("deleting the suspended tenant")
+			Expect(k8sClient.Get(ctx, typeNamespacedName, tenant)).To(Succeed())
+			Expect(k8sClient.Delete(ctx, tenant)).To(Succeed())
+			_, err = controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(k8sClient.Get(ctx, typeNamespacedName, tenant)).To(Succeed())
+			Expect(meta.FindStatusCondition(tenant.Status.Conditions, guardianv1alpha1.CondDeleting).Reason).
+				To(Equal(ReasonTenantSuspended))
+			Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(ns), ns)).To(Succeed())
+			Expect(ns.Labels).To(HaveKeyWithValue(guardianv1alpha1.LabelManaged, "true"))
+
+			By("lifting suspend lets the deletion finish")
+			tenant.Spec.Suspend = false
+			Expect(k8sClient.Update(ctx, tenant)).To(Succeed())
+			_, err = controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(errors.IsNotFound(k8sClient.Get(ctx, typeNamespacedName, tenant))).To(BeTrue())
+			Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(ns), ns)).To(Succeed())
+			Expect(ns.Labels).NotTo(HaveKey(guardianv1alpha1.LabelManaged))
 		})
+
+		It("should delete requests and then namespaces with deletionPolicy=Delete", func() {
+			ns, nr := managedNamespace("test-resource-delete")
+			DeferCleanup(func() {
+				// envtest 没有 namespace controller，namespace 会一直 Terminating：摘掉标签、放掉 tenant finalizer
+				Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(ns), ns)).To(Succeed())
+				ns.Labels = nil
+				Expect(k8sClient.Update(ctx, ns)).To(Succeed())
+				if err := k8sClient.Get(ctx, typeNamespacedName, tenant); err == nil {
+					controllerutil.RemoveFinalizer(tenant, guardianv1alpha1.FinalizerTenant)
+					Expect(k8sClient.Update(ctx, tenant)).To(Succeed())
+				}
+			})
+
+			controllerReconciler := deleteTenant(guardianv1alpha1.DeletionPolicyDelete)
+			By("deleting the requests first")
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(k8sClient.Get(ctx, typeNamespacedName, tenant)).To(Succeed())
+			Expect(meta.FindStatusCondition(tenant.Status.Conditions, guardianv1alpha1.CondDeleting).Reason).
+				To(Equal(ReasonDeletingRequests))
+			Expect(errors.IsNotFound(k8sClient.Get(ctx, client.ObjectKeyFromObject(nr), nr))).To(BeTrue())
+			Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(ns), ns)).To(Succeed())
+			Expect(ns.DeletionTimestamp).To(BeNil())
+
+			By("then deleting the namespaces and waiting for them to go away")
+			_, err = controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(k8sClient.Get(ctx, typeNamespacedName, tenant)).To(Succeed())
+			Expect(meta.FindStatusCondition(tenant.Status.Conditions, guardianv1alpha1.CondDeleting).Reason).
+				To(Equal(ReasonDeletingNamespaces))
+			Expect(tenant.Finalizers).To(ContainElement(guardianv1alpha1.FinalizerTenant))
+			Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(ns), ns)).To(Succeed())
+			Expect(ns.DeletionTimestamp).NotTo(BeNil())
+		})
+	})
+})
+
+var _ = Describe("Tenant rollout", func() {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	var t *guardianv1alpha1.Tenant
+
+	request := func(name, env string, observedTenantGen int64) guardianv1alpha1.NamespaceRequest {
+		nr := guardianv1alpha1.NamespaceRequest{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec:       guardianv1alpha1.NamespaceRequestSpec{Env: env},
+		}
+		nr.Status.NamespaceName = name
+		nr.Status.Phase = guardianv1alpha1.PhaseProvisioned
+		nr.Status.ObservedTenantGeneration = observedTenantGen
+		return nr
+	}
+	// observe：request 按当前 Tenant baseline 下发完成
+	observe := func(nr *guardianv1alpha1.NamespaceRequest) {
+		nr.Status.ObservedTenantGeneration = t.Generation
+		nr.Status.ObservedTenantSpecHash = guardianv1alpha1.RolloutSpecHash(&t.Spec)
+	}
+	admittedNames := func(ro *guardianv1alpha1.TenantRolloutStatus) []string {
+		var out []string
+		for _, a := range ro.Admitted {
+			out = append(out, a.Request)
+		}
+		return out
+	}
+
+	BeforeEach(func() {
+		t = &guardianv1alpha1.Tenant{
+			ObjectMeta: metav1.ObjectMeta{Name: "rollout", Generation: 2},
+			Spec: guardianv1alpha1.TenantSpec{
+				Rollout: &guardianv1alpha1.TenantRolloutSpec{
+					SoakPeriod:   &metav1.Duration{Duration: time.Hour},
+					MaxPerMinute: 2,
+				},
+			},
+		}
+	})
+
+	It("should roll out env by env, rate limited, with a soak period between stages", func() {
+		reqs := []guardianv1alpha1.NamespaceRequest{
+			request("prod-a", "prod", 1),
+			request("dev-a", "dev", 1), request("dev-b", "dev", 1), request("dev-c", "dev", 1),
+			request("new", "dev", 0),
+		}
+		reqs[4].Status.NamespaceName = "" // 还没下发过的 request 不参与 rollout
+
+		By("admitting at most maxPerMinute dev requests")
+		ro, requeue := planRollout(t, reqs, now)
+		Expect(ro.Phase).To(Equal(guardianv1alpha1.RolloutProgressing))
+		Expect(ro.Stage).To(Equal("dev"))
+		Expect(ro.Total).To(BeEquivalentTo(4))
+		Expect(admittedNames(ro)).To(Equal([]string{"dev-a", "dev-b"}))
+		Expect(requeue).To(Equal(time.Minute))
+		Expect(rolloutAdmitted(&guardianv1alpha1.Tenant{Spec: t.Spec, Status: guardianv1alpha1.TenantStatus{Rollout: ro},
+			ObjectMeta: t.ObjectMeta}, &reqs[2])).To(BeTrue())
+		Expect(rolloutAdmitted(&guardianv1alpha1.Tenant{Spec: t.Spec, Status: guardianv1alpha1.TenantStatus{Rollout: ro},
+			ObjectMeta: t.ObjectMeta}, &reqs[3])).To(BeFalse())
+
+		By("admitting the next one once the window has passed")
+		t.Status.Rollout = ro
+		reqs[1].Status.ObservedTenantGeneration = 2
+		reqs[2].Status.ObservedTenantGeneration = 2
+		ro, _ = planRollout(t, reqs, now.Add(time.Minute))
+		Expect(admittedNames(ro)).To(Equal([]string{"dev-c"}))
+		Expect(ro.Updated).To(BeEquivalentTo(2))
+
+		By("soaking before prod")
+		t.Status.Rollout = ro
+		reqs[3].Status.ObservedTenantGeneration = 2
+		ro, requeue = planRollout(t, reqs, now.Add(2*time.Minute))
+		Expect(ro.Phase).To(Equal(guardianv1alpha1.RolloutSoaking))
+		Expect(ro.Stage).To(Equal("prod"))
+		Expect(requeue).To(Equal(time.Hour))
+		Expect(admittedNames(ro)).NotTo(ContainElement("prod-a"))
+
+		By("admitting prod after the soak period")
+		t.Status.Rollout = ro
+		ro, _ = planRollout(t, reqs, now.Add(2*time.Minute+time.Hour))
+		Expect(ro.Phase).To(Equal(guardianv1alpha1.RolloutProgressing))
+		Expect(ro.SoakUntil).To(BeNil())
+		Expect(admittedNames(ro)).To(Equal([]string{"prod-a"}))
+
+		By("completing")
+		t.Status.Rollout = ro
+		reqs[0].Status.ObservedTenantGeneration = 2
+		ro, _ = planRollout(t, reqs, now.Add(3*time.Minute+time.Hour))
+		Expect(ro.Phase).To(Equal(guardianv1alpha1.RolloutComplete))
+		Expect(ro.Updated).To(BeEquivalentTo(4))
+		Expect(ro.Admitted).To(BeEmpty())
+	})
+
+	It("should halt on BaselineFailed and admit nothing while paused", func() {
+		reqs := []guardianv1alpha1.NamespaceRequest{request("dev-a", "dev", 1), request("dev-b", "dev", 1), request("dev-c", "dev", 1)}
+		t.Spec.Rollout.MaxPerMinute = 1
+		ro, _ := planRollout(t, reqs, now)
+		Expect(admittedNames(ro)).To(Equal([]string{"dev-a"}))
+
+		By("halting when the admitted request fails")
+		t.Status.Rollout = ro
+		reqs[0].Status.Phase = guardianv1alpha1.PhaseRetrying
+		reqs[0].Status.Reason = "BaselineFailed"
+		ro, _ = planRollout(t, reqs, now.Add(2*time.Minute))
+		Expect(ro.Phase).To(Equal(guardianv1alpha1.RolloutHalted))
+		Expect(ro.Message).To(ContainSubstring("dev-a"))
+		Expect(admittedNames(ro)).To(Equal([]string{"dev-a"}))
+
+		By("pausing once the failure is fixed (paused is part of the spec and bumps the generation)")
+		t.Status.Rollout = ro
+		reqs[0].Status.Reason = "Provisioned"
+		observe(&reqs[0])
+		t.Spec.Rollout.Paused = true
+		t.Generation = 3
+		ro, _ = planRollout(t, reqs, now.Add(3*time.Minute))
+		Expect(ro.Phase).To(Equal(guardianv1alpha1.RolloutPaused))
+		Expect(ro.Stage).To(Equal("dev"))
+		Expect(ro.Updated).To(BeEquivalentTo(1))
+		Expect(ro.Admitted).To(BeEmpty())
+
+		By("resuming continues where the rollout stopped")
+		t.Status.Rollout = ro
+		t.Spec.Rollout.Paused = false
+		t.Generation = 4
+		ro, _ = planRollout(t, reqs, now.Add(4*time.Minute))
+		Expect(ro.Phase).To(Equal(guardianv1alpha1.RolloutProgressing))
+		Expect(ro.Updated).To(BeEquivalentTo(1))
+		Expect(admittedNames(ro)).To(Equal([]string{"dev-b"}))
+		Expect(rolloutAdmitted(t, &reqs[0])).To(BeTrue())
+
+		By("a baseline change restarts the rollout")
+		t.Status.Rollout = ro
+		t.Spec.Baseline = &guardianv1alpha1.TenantBaselineSpec{Version: guardianv1alpha1.BaselineVersionV2}
+		t.Generation = 5
+		ro, _ = planRollout(t, reqs, now.Add(5*time.Minute))
+		Expect(ro.Generation).To(BeEquivalentTo(5))
+		Expect(ro.Updated).To(BeZero())
+		Expect(admittedNames(ro)).To(Equal([]string{"dev-a"}))
+	})
+
+	It("should not start a rollout when only fields outside spec.baseline change", func() {
+		reqs := []guardianv1alpha1.NamespaceRequest{request("dev-a", "dev", 1), request("prod-a", "prod", 1)}
+		for i := range reqs {
+			observe(&reqs[i])
+		}
+
+		By("suspending and lifting the suspend")
+		t.Spec.Suspend = true
+		t.Generation = 3
+		ro, _ := planRollout(t, reqs, now)
+		Expect(ro.Phase).To(Equal(guardianv1alpha1.RolloutComplete))
+		t.Status.Rollout = ro
+		t.Spec.Suspend = false
+		t.Generation = 4
+		ro, _ = planRollout(t, reqs, now.Add(time.Minute))
+		Expect(ro.Phase).To(Equal(guardianv1alpha1.RolloutComplete))
+		Expect(ro.Updated).To(BeEquivalentTo(2))
+		Expect(ro.Admitted).To(BeEmpty())
+
+		By("editing allowedGroups and approval")
+		t.Status.Rollout = ro
+		t.Spec.AllowedGroups = append(t.Spec.AllowedGroups, "rollout:dev")
+		t.Spec.Approval = &guardianv1alpha1.TenantApprovalSpec{Envs: []string{guardianv1alpha1.EnvProd}}
+		t.Generation = 5
+		ro, _ = planRollout(t, reqs, now.Add(2*time.Minute))
+		Expect(ro.Phase).To(Equal(guardianv1alpha1.RolloutComplete))
+		for i := range reqs {
+			Expect(rolloutAdmitted(t, &reqs[i])).To(BeTrue())
+		}
 	})
 })
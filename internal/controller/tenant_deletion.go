@@ -0,0 +1,224 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	guardianv1alpha1 "github.com/CATDOGME/namespace-guardian/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// Tenant deletion condition reasons
+const (
+	ReasonDeletionBlocked    = "DeletionBlocked"
+	ReasonDeletingRequests   = "DeletingRequests"
+	ReasonDeletingNamespaces = "DeletingNamespaces"
+	ReasonOrphaning          = "Orphaning"
+)
+
+// deletion 过程中等待 namespace 真正消失的轮询间隔
+const tenantDeletionRequeue = 5 * time.Second
+
+// reconcileDelete 按 spec.deletionPolicy 处理 Tenant 删除，完成后移除 finalizer
+func (r *TenantReconciler) reconcileDelete(ctx context.Context, t *guardianv1alpha1.Tenant) (ctrl.Result, error) {
+	l := logf.FromContext(ctx)
+
+	if !controllerutil.ContainsFinalizer(t, guardianv1alpha1.FinalizerTenant) {
+		return ctrl.Result{}, nil
+	}
+
+	// suspend：暂停一切破坏性操作（删 request/namespace、摘标签），解除后继续
+	if t.Spec.Suspend {
+		return ctrl.Result{}, r.setDeletingCondition(ctx, t, ReasonTenantSuspended,
+			"tenant is suspended, deletion is paused until spec.suspend is cleared")
+	}
+
+	nsList, reqList, err := r.listTenantObjects(ctx, t.Name)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	policy := t.Spec.DeletionPolicy
+	if policy == "" {
+		policy = guardianv1alpha1.DeletionPolicyBlock
+	}
+
+	switch policy {
+	case guardianv1alpha1.DeletionPolicyOrphan:
+		// 先摘掉 managed 标签，再删 request（避免 request 侧回收 namespace）
+		for i := range nsList.Items {
+			if err := orphanNamespace(ctx, r.Client, &nsList.Items[i], reqList.Items); err != nil {
+				return ctrl.Result{}, r.setDeletingCondition(ctx, t, ReasonOrphaning, err.Error())
+			}
+		}
+		if err := r.deleteRequests(ctx, reqList.Items); err != nil {
+			return ctrl.Result{}, err
+		}
+		l.Info("tenant namespaces orphaned", "tenant", t.Name, "namespaces", len(nsList.Items))
+
+	case guardianv1alpha1.DeletionPolicyDelete:
+		// 顺序：先 request，再 namespace
+		if len(reqList.Items) > 0 {
+			if err := r.deleteRequests(ctx, reqList.Items); err != nil {
+				return ctrl.Result{}, err
+			}
+			msg := fmt.Sprintf("waiting for %d namespace requests to be deleted", len(reqList.Items))
+			return ctrl.Result{RequeueAfter: tenantDeletionRequeue}, r.setDeletingCondition(ctx, t, ReasonDeletingRequests, msg)
+		}
+		// 之前被 request 以 Retain 方式留下的 orphaned namespace 一并删除
+		var orphaned corev1.NamespaceList
+		if err := r.List(ctx, &orphaned, client.MatchingLabels{
+			guardianv1alpha1.LabelTenant:   t.Name,
+			guardianv1alpha1.LabelOrphaned: "true",
+		}); err != nil {
+			return ctrl.Result{}, err
+		}
+		nsList.Items = append(nsList.Items, orphaned.Items...)
+		if len(nsList.Items) > 0 {
+			for i := range nsList.Items {
+				ns := &nsList.Items[i]
+				if !ns.DeletionTimestamp.IsZero() {
+					continue
+				}
+				if err := r.Delete(ctx, ns); err != nil && !apierrors.IsNotFound(err) {
+					return ctrl.Result{}, err
+				}
+			}
+			msg := fmt.Sprintf("waiting for %d namespaces to be deleted", len(nsList.Items))
+			return ctrl.Result{RequeueAfter: tenantDeletionRequeue}, r.setDeletingCondition(ctx, t, ReasonDeletingNamespaces, msg)
+		}
+
+	default:
+		// Block：还有 namespace/request 就拒绝删除，等用户先清理
+		if len(nsList.Items) > 0 || len(reqList.Items) > 0 {
+			msg := fmt.Sprintf("deletionPolicy=Block: %d namespaces and %d namespace requests still exist",
+				len(nsList.Items), len(reqList.Items))
+			return ctrl.Result{}, r.setDeletingCondition(ctx, t, ReasonDeletionBlocked, msg)
+		}
+	}
+
+	controllerutil.RemoveFinalizer(t, guardianv1alpha1.FinalizerTenant)
+	if err := r.Update(ctx, t); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	l.Info("tenant finalized", "tenant", t.Name, "deletionPolicy", policy)
+	return ctrl.Result{}, nil
+}
+
+func (r *TenantReconciler) listTenantObjects(ctx context.Context, tenant string) (*corev1.NamespaceList, *guardianv1alpha1.NamespaceRequestList, error) {
+	var nsList corev1.NamespaceList
+	if err := r.List(ctx, &nsList, client.MatchingLabels{
+		guardianv1alpha1.LabelTenant:  tenant,
+		guardianv1alpha1.LabelManaged: "true",
+	}); err != nil {
+		return nil, nil, err
+	}
+	var reqList guardianv1alpha1.NamespaceRequestList
+	if err := r.List(ctx, &reqList, client.MatchingLabels{
+		guardianv1alpha1.LabelTenant: tenant,
+	}); err != nil {
+		return nil, nil, err
+	}
+	return &nsList, &reqList, nil
+}
+
+func (r *TenantReconciler) deleteRequests(ctx context.Context, reqs []guardianv1alpha1.NamespaceRequest) error {
+	for i := range reqs {
+		if !reqs[i].DeletionTimestamp.IsZero() {
+			continue
+		}
+		if err := r.Delete(ctx, &reqs[i]); err != nil && !apierrors.IsNotFound(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *TenantReconciler) setDeletingCondition(ctx context.Context, t *guardianv1alpha1.Tenant, reason, msg string) error {
+	changed := meta.SetStatusCondition(&t.Status.Conditions, metav1.Condition{
+		Type:               guardianv1alpha1.CondDeleting,
+		Status:             metav1.ConditionTrue,
+		Reason:             reason,
+		Message:            msg,
+		ObservedGeneration: t.Generation,
+	})
+	if !changed {
+		return nil
+	}
+	return r.Status().Update(ctx, t)
+}
+
+// orphanNamespace 去掉 namespace 及其 baseline 对象（RBAC、ServiceAccount、复制的 Secret/ConfigMap、quota、
+// LimitRange、NetworkPolicy、manifests）上的 guardian.io/managed 标签，之后不会再被当成 guardian 的对象覆盖或清理
+func orphanNamespace(ctx context.Context, c client.Client, ns *corev1.Namespace, reqs []guardianv1alpha1.NamespaceRequest) error {
+	managed := client.MatchingLabels{guardianv1alpha1.LabelManaged: "true"}
+	inNS := client.InNamespace(ns.Name)
+
+	lists := []client.ObjectList{&corev1.SecretList{}, &corev1.ConfigMapList{}}
+	for _, k := range prunedKinds {
+		lists = append(lists, k.newList())
+	}
+	for _, list := range lists {
+		if err := c.List(ctx, list, inNS, managed); err != nil {
+			return err
+		}
+		items, err := meta.ExtractList(list)
+		if err != nil {
+			return err
+		}
+		for _, item := range items {
+			if obj, ok := item.(client.Object); ok {
+				if err := stripManagedLabel(ctx, c, obj); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	// manifests 的 kind 不固定，按 request status 里记录的对象处理
+	for i := range reqs {
+		if reqs[i].Status.NamespaceName != ns.Name {
+			continue
+		}
+		for _, m := range reqs[i].Status.Manifests {
+			if !m.Applied || m.Kind == "" {
+				continue
+			}
+			obj := &unstructured.Unstructured{}
+			obj.SetAPIVersion(m.APIVersion)
+			obj.SetKind(m.Kind)
+			if err := c.Get(ctx, types.NamespacedName{Namespace: ns.Name, Name: m.ObjectName}, obj); err != nil {
+				if apierrors.IsNotFound(err) {
+					continue
+				}
+				return err
+			}
+			if err := stripManagedLabel(ctx, c, obj); err != nil {
+				return err
+			}
+		}
+	}
+
+	// namespace 最后处理：失败重试时还能再次被 list 到
+	return stripManagedLabel(ctx, c, ns)
+}
+
+func stripManagedLabel(ctx context.Context, c client.Client, obj client.Object) error {
+	labels := obj.GetLabels()
+	if _, ok := labels[guardianv1alpha1.LabelManaged]; !ok {
+		return nil
+	}
+	delete(labels, guardianv1alpha1.LabelManaged)
+	obj.SetLabels(labels)
+	return client.IgnoreNotFound(c.Update(ctx, obj))
+}
@@ -0,0 +1,218 @@
+package controller
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	guardianv1alpha1 "github.com/CATDOGME/namespace-guardian/api/v1alpha1"
+)
+
+// maxPerMinute 的统计窗口
+const rolloutWindow = time.Minute
+
+// planRollout：计算 Tenant 当前 baseline（spec.baseline 的 hash）在已 Provisioned 的 namespace 上的 rollout 进度，并放行下一批
+// - 按 stages 逐个 env 推进，上一个 env 全部更新完、soakPeriod 过后才开始下一个
+// - 每分钟最多放行 maxPerMinute 个；paused 时不再放行
+// - 已放行的 request 报告 BaselineFailed 时自动停止（Halted），恢复后继续
+// 返回新的 rollout status 与下一次需要检查的等待时间（0 表示等 Watch 触发）
+func planRollout(t *guardianv1alpha1.Tenant, reqs []guardianv1alpha1.NamespaceRequest, now time.Time) (*guardianv1alpha1.TenantRolloutStatus, time.Duration) {
+	spec := t.Spec.Rollout
+	if spec == nil {
+		return nil, 0
+	}
+	hash := guardianv1alpha1.RolloutSpecHash(&t.Spec)
+	ro := &guardianv1alpha1.TenantRolloutStatus{Generation: t.Generation, SpecHash: hash}
+	// 只改 rollout（暂停/恢复/调速）也会涨 generation，但 baseline 没变，进度保留
+	if prev := t.Status.Rollout; sameRollout(prev, t, hash) {
+		ro.Stage, ro.SoakUntil, ro.Admitted = prev.Stage, prev.SoakUntil, prev.Admitted
+	}
+
+	// 待更新：已经按旧 baseline 下发过的 request，按 env 分组
+	pending := map[string]*guardianv1alpha1.NamespaceRequest{}
+	byEnv := map[string][]string{}
+	for i := range reqs {
+		nr := &reqs[i]
+		if !baselineProvisioned(nr) || !nr.DeletionTimestamp.IsZero() {
+			continue
+		}
+		ro.Total++
+		if tenantObserved(t, nr, hash) {
+			ro.Updated++
+			continue
+		}
+		pending[nr.Name] = nr
+		env := requestEnv(nr)
+		byEnv[env] = append(byEnv[env], nr.Name)
+	}
+
+	// admitted：保留仍在更新中的，以及窗口内放行的（用于限速）
+	var admitted []guardianv1alpha1.RolloutAdmission
+	var failed []string
+	var oldestInWindow *time.Time
+	inWindow := 0
+	for _, a := range ro.Admitted {
+		nr, updating := pending[a.Request]
+		recent := now.Sub(a.AdmittedAt.Time) < rolloutWindow
+		if !updating && !recent {
+			continue
+		}
+		admitted = append(admitted, a)
+		if recent {
+			inWindow++
+			if oldestInWindow == nil || a.AdmittedAt.Time.Before(*oldestInWindow) {
+				oldestInWindow = &a.AdmittedAt.Time
+			}
+		}
+		if updating && nr.Status.Reason == ReasonBaselineFailed {
+			failed = append(failed, a.Request)
+		}
+	}
+	ro.Admitted = admitted
+
+	if len(failed) > 0 {
+		ro.Phase = guardianv1alpha1.RolloutHalted
+		ro.Message = fmt.Sprintf("halted: %s reported %s", strings.Join(failed, ","), ReasonBaselineFailed)
+		return ro, 0
+	}
+
+	// 当前阶段：第一个还有待更新 request 的 env（不在 stages 里的 env 排在最后）
+	stages := guardianv1alpha1.RolloutStages(spec)
+	for _, env := range slices.Sorted(maps.Keys(byEnv)) {
+		if !slices.Contains(stages, env) {
+			stages = append(stages, env)
+		}
+	}
+	stage := ""
+	for _, env := range stages {
+		if len(byEnv[env]) > 0 {
+			stage = env
+			break
+		}
+	}
+	if stage == "" {
+		ro.Phase = guardianv1alpha1.RolloutComplete
+		ro.Stage, ro.SoakUntil, ro.Admitted = "", nil, nil
+		ro.Message = fmt.Sprintf("all %d namespaces updated", ro.Total)
+		return ro, 0
+	}
+
+	// 进入新阶段：上一阶段刚完成，开始 soak
+	if stage != ro.Stage {
+		if ro.Stage != "" && spec.SoakPeriod != nil && spec.SoakPeriod.Duration > 0 {
+			ro.SoakUntil = &metav1.Time{Time: now.Add(spec.SoakPeriod.Duration)}
+		}
+		ro.Stage = stage
+	}
+	progress := fmt.Sprintf("stage %s: %d/%d namespaces updated", stage, ro.Updated, ro.Total)
+
+	if spec.Paused {
+		ro.Phase = guardianv1alpha1.RolloutPaused
+		ro.Message = "paused, " + progress
+		return ro, 0
+	}
+	if ro.SoakUntil != nil {
+		if wait := ro.SoakUntil.Sub(now); wait > 0 {
+			ro.Phase = guardianv1alpha1.RolloutSoaking
+			ro.Message = fmt.Sprintf("soaking until %s before %s", ro.SoakUntil.UTC().Format(time.RFC3339), progress)
+			return ro, wait
+		}
+		ro.SoakUntil = nil
+	}
+
+	ro.Phase = guardianv1alpha1.RolloutProgressing
+	ro.Message = progress
+	names := byEnv[stage]
+	sort.Strings(names)
+	waiting := 0
+	for _, name := range names {
+		if slices.ContainsFunc(ro.Admitted, func(a guardianv1alpha1.RolloutAdmission) bool { return a.Request == name }) {
+			continue
+		}
+		if spec.MaxPerMinute > 0 && inWindow >= int(spec.MaxPerMinute) {
+			waiting++
+			continue
+		}
+		ro.Admitted = append(ro.Admitted, guardianv1alpha1.RolloutAdmission{Request: name, AdmittedAt: metav1.NewTime(now)})
+		if oldestInWindow == nil {
+			oldestInWindow = &now
+		}
+		inWindow++
+	}
+
+	// 限速：窗口内最早的一次放行过期后再放行下一批
+	if waiting > 0 && oldestInWindow != nil {
+		return ro, max(oldestInWindow.Add(rolloutWindow).Sub(now), time.Second)
+	}
+	return ro, 0
+}
+
+// rolloutAdmitted：Tenant 配置了 rollout 时，已按旧 baseline 下发过的 namespace 要等 Tenant controller 放行
+func rolloutAdmitted(t *guardianv1alpha1.Tenant, nr *guardianv1alpha1.NamespaceRequest) bool {
+	if t.Spec.Rollout == nil || !baselineProvisioned(nr) || tenantObserved(t, nr, guardianv1alpha1.RolloutSpecHash(&t.Spec)) {
+		return true
+	}
+	return slices.Contains(admittedRequests(t), nr.Name)
+}
+
+// admittedRequests：当前 baseline 已放行的 request（status 还停留在旧 baseline 时视为没有）
+func admittedRequests(t *guardianv1alpha1.Tenant) []string {
+	ro := t.Status.Rollout
+	if !sameRollout(ro, t, guardianv1alpha1.RolloutSpecHash(&t.Spec)) {
+		return nil
+	}
+	out := make([]string, 0, len(ro.Admitted))
+	for _, a := range ro.Admitted {
+		out = append(out, a.Request)
+	}
+	return out
+}
+
+// rolloutAdmissionChanged：Tenant controller 放行了新的 request（Tenant status 变化，generation 不变）
+var rolloutAdmissionChanged = predicate.Funcs{
+	UpdateFunc: func(e event.UpdateEvent) bool {
+		o, ok := e.ObjectOld.(*guardianv1alpha1.Tenant)
+		n, ok2 := e.ObjectNew.(*guardianv1alpha1.Tenant)
+		return ok && ok2 && !slices.Equal(admittedRequests(o), admittedRequests(n))
+	},
+}
+
+// baselineProvisioned：request 至少成功下发过一次 baseline（之后变成 Suspended / Failed / Retrying 的也算），
+// 只有这样的 request 参与 rollout；namespace 建好但 baseline 从没成功过的照常下发
+func baselineProvisioned(nr *guardianv1alpha1.NamespaceRequest) bool {
+	return nr.Status.NamespaceName != "" &&
+		(nr.Status.ObservedTenantSpecHash != "" || nr.Status.ObservedTenantGeneration > 0)
+}
+
+// tenantObserved：request 已按当前 baseline 下发过（升级前没有记录 hash 的按 generation 判断）
+func tenantObserved(t *guardianv1alpha1.Tenant, nr *guardianv1alpha1.NamespaceRequest, hash string) bool {
+	if nr.Status.ObservedTenantSpecHash != "" {
+		return nr.Status.ObservedTenantSpecHash == hash
+	}
+	return nr.Status.ObservedTenantGeneration >= t.Generation
+}
+
+// sameRollout：rollout status 对应的仍是当前 baseline（升级前没有记录 hash 的按 generation 判断）
+func sameRollout(ro *guardianv1alpha1.TenantRolloutStatus, t *guardianv1alpha1.Tenant, hash string) bool {
+	if ro == nil {
+		return false
+	}
+	if ro.SpecHash != "" {
+		return ro.SpecHash == hash
+	}
+	return ro.Generation == t.Generation
+}
+
+func requestEnv(nr *guardianv1alpha1.NamespaceRequest) string {
+	if env := strings.TrimSpace(nr.Spec.Env); env != "" {
+		return env
+	}
+	return guardianv1alpha1.EnvDev
+}
@@ -3,23 +3,33 @@ package v1alpha1
 import (
 	"context"
 	"fmt"
+	"maps"
+	"slices"
 	"strings"
+	"time"
 
 	guardianv1alpha1 "github.com/CATDOGME/namespace-guardian/api/v1alpha1"
 
 	admissionv1 "k8s.io/api/admission/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
 	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	admission "sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 )
 
 type NamespaceRequestAuthzValidator struct {
 	Client  client.Client
 	Decoder admission.Decoder
+	// ControllerUsername：controller 自己的用户名（与 webhook 同一进程），只有它能摘掉 guardian 的 finalizer
+	ControllerUsername string
 }
 
 var _ admission.Handler = &NamespaceRequestAuthzValidator{}
@@ -59,21 +69,38 @@ func (v *NamespaceRequestAuthzValidator) validateCreate(ctx context.Context, req
 	namespacerequestlog.Info("AUTHZ_WEBHOOK_HIT",
 		"user", req.UserInfo.Username,
 		"groups", req.UserInfo.Groups,
+		"uid", req.UID,
 		"tenant", tenant,
 		"env", env,
 		"ownerGroup", ownerGroup,
 	)
 
-	// 0) 字段校验
+	// 0) 身份注解：必须与本次 admission 请求一致，不能自带审批记录
+	if by := obj.Annotations[guardianv1alpha1.AnnRequestedBy]; by != "" && by != req.UserInfo.Username {
+		return admission.Denied(fmt.Sprintf("annotation %s must be the requesting user", guardianv1alpha1.AnnRequestedBy))
+	}
+	if groups, ok := obj.Annotations[guardianv1alpha1.AnnRequestedByGroups]; ok && groups != strings.Join(req.UserInfo.Groups, ",") {
+		return admission.Denied(fmt.Sprintf("annotation %s must be the requesting user's groups", guardianv1alpha1.AnnRequestedByGroups))
+	}
+	if uid, ok := obj.Annotations[guardianv1alpha1.AnnRequestUID]; ok && uid != string(req.UID) {
+		return admission.Denied(fmt.Sprintf("annotation %s must be the admission request UID", guardianv1alpha1.AnnRequestUID))
+	}
+	if at, ok := obj.Annotations[guardianv1alpha1.AnnRequestedAt]; ok {
+		if _, err := time.Parse(time.RFC3339, at); err != nil {
+			return admission.Denied(fmt.Sprintf("annotation %s must be an RFC3339 timestamp", guardianv1alpha1.AnnRequestedAt))
+		}
+	}
+	if obj.Annotations[guardianv1alpha1.AnnApprovedBy] != "" {
+		return admission.Denied(fmt.Sprintf("annotation %s cannot be set on create", guardianv1alpha1.AnnApprovedBy))
+	}
+
+	// 0.1) 字段校验
 	if tenant == "" {
 		return admission.Denied("spec.tenant is required")
 	}
 	if ownerGroup == "" {
 		return admission.Denied("spec.ownerGroup is required")
 	}
-	if len(ownerGroup) > 63 {
-		return admission.Denied("spec.ownerGroup too long (max 63)")
-	}
 
 	// env 白名单（可按需缩小，比如只允许 dev/test）
 	switch env {
@@ -91,6 +118,13 @@ func (v *NamespaceRequestAuthzValidator) validateCreate(ctx context.Context, req
 		return admission.Errored(500, err)
 	}
 
+	// 1.1) tenant 处于 suspend 且要求拒绝新申请
+	if t.Spec.Suspend && t.Spec.SuspendDenyCreate {
+		return admission.Denied(fmt.Sprintf(
+			"tenant %q is suspended: new namespace requests are not accepted until suspend is lifted", tenant,
+		))
+	}
+
 	// 2) 租户级准入：用户 groups 必须命中 tenant.spec.allowedGroups
 	if !anyGroupAllowed(req.UserInfo.Groups, t.Spec.AllowedGroups) {
 		return admission.Denied(fmt.Sprintf(
@@ -117,6 +151,53 @@ func (v *NamespaceRequestAuthzValidator) validateCreate(ctx context.Context, req
 		))
 	}
 
+	// 4.0) ownerGroup 必须属于本 tenant（allowedGroups 或 <tenant>: 前缀），不能是 system: 组
+	if msg := validateOwnerGroup(&t, ownerGroup, "spec.ownerGroup"); msg != "" {
+		return admission.Denied(msg)
+	}
+
+	// 4.0.0) size：必须是 Tenant 为该 env 提供的规格
+	if msg := validateQuotaSize(&t, obj.Spec.Size, env); msg != "" {
+		return admission.Denied(msg)
+	}
+
+	// 4.0.1) ttl：不超过 Tenant 对该 env 的上限
+	if msg := validateRequestTTL(&t, obj, env, time.Now()); msg != "" {
+		return admission.Denied(msg)
+	}
+
+	// 4.0.2) extraBindings：role 在 catalog 内、subject 在 allowedGroups 范围内
+	nsName := guardianv1alpha1.NamespaceNameFor(&t.Spec, obj)
+	if resp, denied := v.validateExtraBindings(ctx, &t, obj, nsName); denied {
+		return resp
+	}
+
+	// 4.1) 命名：Template 策略下不允许自定义名称；名称须是合法 DNS label 且匹配 namespaceNamePattern
+	// 接管已有 namespace 时不走命名约束，改为接管校验
+	if strings.TrimSpace(obj.Spec.Adopt) != "" {
+		if resp, denied := v.validateAdopt(ctx, req, &t, obj, nsName); denied {
+			return resp
+		}
+	} else {
+		if requested := strings.TrimSpace(obj.Spec.NamespaceName); requested != "" && !guardianv1alpha1.AllowsRequestedNamespaceName(&t.Spec) {
+			generated := guardianv1alpha1.RenderNamespaceName(&t.Spec, tenant, env, ownerGroup)
+			if requested != generated {
+				return admission.Denied(fmt.Sprintf(
+					"spec.namespaceName %q is not allowed: tenant %q generates namespace names (expected %q)",
+					requested, tenant, generated,
+				))
+			}
+		}
+		if errs := guardianv1alpha1.ValidateNamespaceName(&t.Spec, nsName, field.NewPath("spec", "namespaceName")); len(errs) > 0 {
+			return admission.Denied(errs.ToAggregate().Error())
+		}
+
+		// 4.2) 名称冲突：provision 之前就拒绝，而不是让 controller 落到 Failed
+		if resp, conflict := v.checkNamespaceNameConflict(ctx, obj, nsName); conflict {
+			return resp
+		}
+	}
+
 	// 5) 唯一性：同 (tenant, ownerGroup, env) 只能一个
 	sel := labels.Set{
 		guardianv1alpha1.LabelTenant: tenant,
@@ -148,16 +229,82 @@ func (v *NamespaceRequestAuthzValidator) validateCreate(ctx context.Context, req
 	if err := v.Client.List(ctx, &nsList, &client.ListOptions{LabelSelector: sel}); err != nil {
 		return admission.Errored(500, err)
 	}
-	if len(nsList.Items) > 0 {
+	// orphaned（NamespaceRequest 已删除、Retain 留下的）namespace 可以被重新申请认领
+	for i := range nsList.Items {
+		if nsList.Items[i].Labels[guardianv1alpha1.LabelOrphaned] == "true" {
+			continue
+		}
 		return admission.Denied(fmt.Sprintf(
 			"namespace already exists for tenant=%s ownerGroup=%s env=%s (e.g. %s)",
-			tenant, ownerGroup, env, nsList.Items[0].Name,
+			tenant, ownerGroup, env, nsList.Items[i].Name,
 		))
 	}
 
 	return admission.Allowed("ok")
 }
 
+// checkNamespaceNameConflict：同名 namespace 已被别的 tenant/request 占用，或已有别的 request 申请了同一名称
+func (v *NamespaceRequestAuthzValidator) checkNamespaceNameConflict(ctx context.Context, obj *guardianv1alpha1.NamespaceRequest, nsName string) (admission.Response, bool) {
+	var ns corev1.Namespace
+	err := v.Client.Get(ctx, types.NamespacedName{Name: nsName}, &ns)
+	switch {
+	case err == nil:
+		sameTenant := ns.Labels[guardianv1alpha1.LabelTenant] == obj.Spec.Tenant
+		if !sameTenant || ns.Labels[guardianv1alpha1.LabelOrphaned] != "true" {
+			return admission.Denied(fmt.Sprintf("namespace %q already exists and is not claimable by tenant %q", nsName, obj.Spec.Tenant)), true
+		}
+	case !apierrors.IsNotFound(err):
+		return admission.Errored(500, err), true
+	}
+	return v.checkRequestedNameConflict(ctx, obj, nsName)
+}
+
+// checkRequestedNameConflict：已有别的（未失败的）request 申请/占用了同一 namespace 名称
+func (v *NamespaceRequestAuthzValidator) checkRequestedNameConflict(ctx context.Context, obj *guardianv1alpha1.NamespaceRequest, nsName string) (admission.Response, bool) {
+	var reqList guardianv1alpha1.NamespaceRequestList
+	if err := v.Client.List(ctx, &reqList); err != nil {
+		return admission.Errored(500, err), true
+	}
+	for i := range reqList.Items {
+		exist := &reqList.Items[i]
+		if exist.Name == obj.Name || exist.Status.Phase == guardianv1alpha1.PhaseFailed {
+			continue
+		}
+		if exist.Spec.NamespaceName == nsName || exist.Status.NamespaceName == nsName {
+			return admission.Denied(fmt.Sprintf("namespace name %q is already requested by nsreq=%s", nsName, exist.Name)), true
+		}
+	}
+	return admission.Response{}, false
+}
+
+// validateAdopt：接管需要 Tenant 打开 allowAdoption、申请人属于 <tenant>:ns-admin，
+// 且目标 namespace 存在、未受 guardian 管理、没有别的 request 在申请
+func (v *NamespaceRequestAuthzValidator) validateAdopt(ctx context.Context, req admission.Request, t *guardianv1alpha1.Tenant,
+	obj *guardianv1alpha1.NamespaceRequest, nsName string) (admission.Response, bool) {
+	if !t.Spec.AllowAdoption {
+		return admission.Denied(fmt.Sprintf("tenant %q does not allow adopting existing namespaces", t.Name)), true
+	}
+	adminGroup := t.Name + ":ns-admin"
+	if !contains(req.UserInfo.Groups, adminGroup) {
+		return admission.Denied(fmt.Sprintf("forbidden: adopting namespace %q requires group %q", nsName, adminGroup)), true
+	}
+	if n := strings.TrimSpace(obj.Spec.NamespaceName); n != "" && n != nsName {
+		return admission.Denied(fmt.Sprintf("spec.namespaceName %q must be empty or equal spec.adopt %q", n, nsName)), true
+	}
+
+	var ns corev1.Namespace
+	if err := v.Client.Get(ctx, types.NamespacedName{Name: nsName}, &ns); err != nil {
+		if apierrors.IsNotFound(err) {
+			return admission.Denied(fmt.Sprintf("namespace %q to adopt does not exist", nsName)), true
+		}
+		return admission.Errored(500, err), true
+	}
+	if ns.Labels[guardianv1alpha1.LabelManaged] == "true" || ns.Labels[guardianv1alpha1.LabelOrphaned] == "true" {
+		return admission.Denied(fmt.Sprintf("namespace %q is already known to guardian, request it without spec.adopt", nsName)), true
+	}
+	return v.checkRequestedNameConflict(ctx, obj, nsName)
+}
+
 func (v *NamespaceRequestAuthzValidator) validateUpdate(ctx context.Context, req admission.Request) admission.Response {
 	newObj := &guardianv1alpha1.NamespaceRequest{}
 	if err := v.Decoder.Decode(req, newObj); err != nil {
@@ -168,6 +315,23 @@ func (v *NamespaceRequestAuthzValidator) validateUpdate(ctx context.Context, req
 		return admission.Errored(400, err)
 	}
 
+	// guardian 的 finalizer 只能由 controller 在回收 namespace 后摘掉，否则删除 request 会绕过回收
+	if controllerutil.ContainsFinalizer(oldObj, guardianv1alpha1.FinalizerNamespaceRequest) &&
+		!controllerutil.ContainsFinalizer(newObj, guardianv1alpha1.FinalizerNamespaceRequest) &&
+		req.UserInfo.Username != v.ControllerUsername {
+		return admission.Denied(fmt.Sprintf("finalizer %s is removed by namespace-guardian only", guardianv1alpha1.FinalizerNamespaceRequest))
+	}
+
+	// controller 的元数据写入：只增删 guardian 的 finalizer、消费 guardian.io/retry，不走下面的申请人校验
+	// （controller 的 ServiceAccount 不在任何 tenant 组里）
+	if req.UserInfo.Username == v.ControllerUsername && metadataOnlyUpdate(oldObj, newObj) {
+		return admission.Allowed("metadata-only update")
+	}
+	// 删除进行中：GC 等摘掉各自的 finalizer（guardian 的 finalizer 上面已限定只有 controller 能摘）
+	if oldObj.DeletionTimestamp != nil && finalizersRemovedOnly(oldObj, newObj) {
+		return admission.Allowed("finalizer removal during deletion")
+	}
+
 	// 不可变字段（防绕过）
 	if newObj.Spec.Tenant != oldObj.Spec.Tenant {
 		return admission.Denied("spec.tenant is immutable")
@@ -175,8 +339,25 @@ func (v *NamespaceRequestAuthzValidator) validateUpdate(ctx context.Context, req
 	if newObj.Spec.Env != oldObj.Spec.Env {
 		return admission.Denied("spec.env is immutable")
 	}
-	if newObj.Spec.OwnerGroup != oldObj.Spec.OwnerGroup {
-		return admission.Denied("spec.ownerGroup is immutable")
+	ownerChanged := newObj.Spec.OwnerGroup != oldObj.Spec.OwnerGroup
+	if ownerChanged && !isTransferAccept(oldObj, newObj) {
+		return admission.Denied(fmt.Sprintf(
+			"spec.ownerGroup is immutable, transfer it with annotations %s and %s",
+			guardianv1alpha1.AnnTransferTo, guardianv1alpha1.AnnTransferAccept,
+		))
+	}
+	if newObj.Spec.Adopt != oldObj.Spec.Adopt {
+		return admission.Denied("spec.adopt is immutable")
+	}
+	// 老对象可能还没有 namespaceName，只允许 defaulting 按 status 回填一次
+	if newObj.Spec.NamespaceName != oldObj.Spec.NamespaceName && !namespaceNameBackfilled(oldObj, newObj) {
+		return admission.Denied("spec.namespaceName is immutable")
+	}
+
+	for _, k := range guardianv1alpha1.RequesterAnnotationKeys {
+		if newObj.Annotations[k] != oldObj.Annotations[k] {
+			return admission.Denied(fmt.Sprintf("annotation %s is immutable", k))
+		}
 	}
 
 	tenant := strings.TrimSpace(newObj.Spec.Tenant)
@@ -198,6 +379,19 @@ func (v *NamespaceRequestAuthzValidator) validateUpdate(ctx context.Context, req
 		return admission.Errored(500, err)
 	}
 
+	// 审批：approved-by 有变化时走审批人校验（审批人通常不在 ownerGroup 里，不走下面的申请人校验）
+	if newObj.Annotations[guardianv1alpha1.AnnApprovedBy] != oldObj.Annotations[guardianv1alpha1.AnnApprovedBy] {
+		return validateApproval(req, &t, oldObj, newObj)
+	}
+
+	// 移交接受：接受人是新 owner 组成员或 tenant admin，不走下面的（旧）owner 校验
+	if ownerChanged {
+		return v.validateTransferAccept(ctx, req, &t, oldObj, newObj)
+	}
+	if newObj.Annotations[guardianv1alpha1.AnnTransferAcceptedBy] != oldObj.Annotations[guardianv1alpha1.AnnTransferAcceptedBy] {
+		return admission.Denied(fmt.Sprintf("annotation %s is written by the webhook only", guardianv1alpha1.AnnTransferAcceptedBy))
+	}
+
 	// 租户级准入
 	if !anyGroupAllowed(req.UserInfo.Groups, t.Spec.AllowedGroups) {
 		return admission.Denied("forbidden: not allowed for this tenant")
@@ -218,9 +412,385 @@ func (v *NamespaceRequestAuthzValidator) validateUpdate(ctx context.Context, req
 		return admission.Denied("forbidden: ownerGroup must be one of your groups")
 	}
 
+	// 移交发起：由当前 owner（上面已校验属于 ownerGroup）发起，发起人由 mutating webhook 记录
+	if resp, denied := validateTransferRequest(req, &t, oldObj, newObj); denied {
+		return resp
+	}
+
+	// 接管确认：只有 tenant admin 能确认
+	if newObj.Annotations[guardianv1alpha1.AnnAdoptConfirm] != oldObj.Annotations[guardianv1alpha1.AnnAdoptConfirm] &&
+		!contains(req.UserInfo.Groups, adminGroup) {
+		return admission.Denied(fmt.Sprintf("forbidden: confirming an adoption requires group %q", adminGroup))
+	}
+
+	// size 调整：owner 校验之后检查规格是否提供，controller 只会调整 ResourceQuota
+	if newObj.Spec.Size != oldObj.Spec.Size {
+		if msg := validateQuotaSize(&t, newObj.Spec.Size, env); msg != "" {
+			return admission.Denied(msg)
+		}
+	}
+
+	// extraBindings 调整：owner 校验之后检查 role catalog 与 subject 范围
+	if !equality.Semantic.DeepEqual(oldObj.Spec.ExtraBindings, newObj.Spec.ExtraBindings) {
+		if resp, denied := v.validateExtraBindings(ctx, &t, newObj, guardianv1alpha1.NamespaceNameFor(&t.Spec, newObj)); denied {
+			return resp
+		}
+	}
+
+	// ttl 延长/修改：与其他 update 同样的 owner 校验之后，再检查上限
+	if _, pending := newObj.Annotations[guardianv1alpha1.AnnExtendTTL]; pending {
+		return admission.Denied(fmt.Sprintf("annotation %s must be a positive duration such as 72h", guardianv1alpha1.AnnExtendTTL))
+	}
+	if !equality.Semantic.DeepEqual(oldObj.Spec.TTL, newObj.Spec.TTL) {
+		if msg := validateRequestTTL(&t, newObj, env, time.Now()); msg != "" {
+			return admission.Denied(msg)
+		}
+	}
+
 	return admission.Allowed("ok")
 }
 
+// metadataOnlyUpdate：除增删 guardian 的 finalizer、移除 guardian.io/retry 注解之外 spec/labels/annotations 都没有变化
+// （老对象的 spec.namespaceName 由 mutating webhook 按 status 回填；tenant/env 等 selector 标签只能回填成 spec 对应的值）
+func metadataOnlyUpdate(oldObj, newObj *guardianv1alpha1.NamespaceRequest) bool {
+	spec := newObj.Spec.DeepCopy()
+	if namespaceNameBackfilled(oldObj, newObj) {
+		spec.NamespaceName = ""
+	}
+	if !equality.Semantic.DeepEqual(oldObj.Spec, *spec) {
+		return false
+	}
+	if !slices.Equal(otherFinalizers(oldObj.Finalizers), otherFinalizers(newObj.Finalizers)) {
+		return false
+	}
+	oldLabels, newLabels := maps.Clone(oldObj.Labels), maps.Clone(newObj.Labels)
+	for k, v := range selectorLabels(newObj) {
+		if got, ok := newLabels[k]; ok && got != v {
+			return false
+		}
+		delete(oldLabels, k)
+		delete(newLabels, k)
+	}
+	if !maps.Equal(oldLabels, newLabels) {
+		return false
+	}
+	oldAnn := maps.Clone(oldObj.Annotations)
+	if _, ok := newObj.Annotations[guardianv1alpha1.AnnRetry]; !ok {
+		delete(oldAnn, guardianv1alpha1.AnnRetry)
+	}
+	return maps.Equal(oldAnn, newObj.Annotations)
+}
+
+// finalizersRemovedOnly：只摘掉了 finalizer，其余 spec/labels/annotations 都没有变化
+func finalizersRemovedOnly(oldObj, newObj *guardianv1alpha1.NamespaceRequest) bool {
+	for _, f := range newObj.Finalizers {
+		if !slices.Contains(oldObj.Finalizers, f) {
+			return false
+		}
+	}
+	return len(newObj.Finalizers) < len(oldObj.Finalizers) &&
+		equality.Semantic.DeepEqual(oldObj.Spec, newObj.Spec) &&
+		maps.Equal(oldObj.Labels, newObj.Labels) && maps.Equal(oldObj.Annotations, newObj.Annotations)
+}
+
+// namespaceNameBackfilled：老对象没有 spec.namespaceName，mutating webhook 按 status 里实际的 namespace 回填
+func namespaceNameBackfilled(oldObj, newObj *guardianv1alpha1.NamespaceRequest) bool {
+	return oldObj.Spec.NamespaceName == "" && oldObj.Status.NamespaceName != "" &&
+		newObj.Spec.NamespaceName == oldObj.Status.NamespaceName
+}
+
+// otherFinalizers：除 guardian 自己的 finalizer 之外的 finalizer
+func otherFinalizers(finalizers []string) []string {
+	return slices.DeleteFunc(slices.Clone(finalizers), func(f string) bool {
+		return f == guardianv1alpha1.FinalizerNamespaceRequest
+	})
+}
+
+// selectorLabels：mutating webhook 按 spec 回填的 selector 标签（requestsForTenant 等按它们查询）
+func selectorLabels(nr *guardianv1alpha1.NamespaceRequest) map[string]string {
+	return map[string]string{
+		guardianv1alpha1.LabelTenant:         nr.Spec.Tenant,
+		guardianv1alpha1.LabelEnv:            nr.Spec.Env,
+		guardianv1alpha1.LabelOwnerGroupHash: guardianv1alpha1.ShortHash16(nr.Spec.OwnerGroup),
+		guardianv1alpha1.LabelManaged:        "true",
+	}
+}
+
+// validateQuotaSize：返回拒绝原因，空串表示通过（未指定 size 时总是通过）
+func validateQuotaSize(t *guardianv1alpha1.Tenant, size, env string) string {
+	size = strings.TrimSpace(size)
+	if size == "" {
+		return ""
+	}
+	if _, ok := guardianv1alpha1.QuotaSize(&t.Spec, env, size); !ok {
+		return fmt.Sprintf("spec.size %q is not offered by tenant %q for env %q (available: %v)",
+			size, t.Name, env, guardianv1alpha1.QuotaSizeNames(&t.Spec, env))
+	}
+	return ""
+}
+
+// validateRequestTTL：返回拒绝原因，空串表示通过；上限约束的是“从现在起的剩余存活时间”
+func validateRequestTTL(t *guardianv1alpha1.Tenant, nr *guardianv1alpha1.NamespaceRequest, env string, now time.Time) string {
+	maxTTL := guardianv1alpha1.MaxTTL(&t.Spec, env)
+	if nr.Spec.TTL == nil {
+		if maxTTL > 0 {
+			return fmt.Sprintf("spec.ttl is required for env %q in tenant %q (max %s)", env, t.Name, maxTTL)
+		}
+		return ""
+	}
+	if nr.Spec.TTL.Duration <= 0 {
+		return "spec.ttl must be positive"
+	}
+	if maxTTL <= 0 {
+		return ""
+	}
+	created := nr.CreationTimestamp.Time
+	if created.IsZero() {
+		created = now
+	}
+	if remaining := created.Add(nr.Spec.TTL.Duration).Sub(now); remaining > maxTTL {
+		return fmt.Sprintf("spec.ttl leaves %s until expiry, tenant %q allows at most %s for env %q",
+			remaining.Round(time.Second), t.Name, maxTTL, env)
+	}
+	return ""
+}
+
+// validateExtraBindings：
+// - role 须在 Tenant baseline.rbac.roleCatalog 中，或是带 guardian.io/role-catalog=true 标签的 ClusterRole
+// - controller 须有该 ClusterRole 的 bind 权限（见 config/rbac/binder.yaml），否则 RoleBinding 会在 reconcile 时被拒
+// - Group 须在 Tenant allowedGroups 中
+// - ServiceAccount 须在本 namespace、同 tenant 管理的 namespace，或 system:serviceaccounts:<ns> 在 allowedGroups 中
+func (v *NamespaceRequestAuthzValidator) validateExtraBindings(ctx context.Context, t *guardianv1alpha1.Tenant,
+	obj *guardianv1alpha1.NamespaceRequest, nsName string) (admission.Response, bool) {
+	seen := map[string]bool{}
+	for i, b := range obj.Spec.ExtraBindings {
+		path := fmt.Sprintf("spec.extraBindings[%d]", i)
+		name := guardianv1alpha1.ExtraBindingName(b)
+		if seen[name] {
+			return admission.Denied(fmt.Sprintf("%s duplicates an earlier binding", path)), true
+		}
+		seen[name] = true
+
+		switch b.Kind {
+		case guardianv1alpha1.ExtraBindingKindGroup:
+			if b.Namespace != "" {
+				return admission.Denied(fmt.Sprintf("%s.namespace must be empty for kind Group", path)), true
+			}
+			if !contains(t.Spec.AllowedGroups, b.Name) {
+				return admission.Denied(fmt.Sprintf("%s: group %q is not in tenant %q allowedGroups %v",
+					path, b.Name, t.Name, t.Spec.AllowedGroups)), true
+			}
+		case guardianv1alpha1.ExtraBindingKindServiceAccount:
+			if b.Namespace != "" && b.Namespace != nsName &&
+				!contains(t.Spec.AllowedGroups, "system:serviceaccounts:"+b.Namespace) {
+				var ns corev1.Namespace
+				err := v.Client.Get(ctx, types.NamespacedName{Name: b.Namespace}, &ns)
+				if err != nil && !apierrors.IsNotFound(err) {
+					return admission.Errored(500, err), true
+				}
+				if err != nil || ns.Labels[guardianv1alpha1.LabelTenant] != t.Name || ns.Labels[guardianv1alpha1.LabelManaged] != "true" {
+					return admission.Denied(fmt.Sprintf(
+						"%s: serviceaccount %s/%s is outside tenant %q (namespace not managed by the tenant and group %q not in allowedGroups)",
+						path, b.Namespace, b.Name, t.Name, "system:serviceaccounts:"+b.Namespace)), true
+				}
+			}
+		default:
+			return admission.Denied(fmt.Sprintf("%s.kind must be Group or ServiceAccount", path)), true
+		}
+
+		if !guardianv1alpha1.InTenantRoleCatalog(&t.Spec, b.Role) {
+			var cr rbacv1.ClusterRole
+			err := v.Client.Get(ctx, types.NamespacedName{Name: b.Role}, &cr)
+			if err != nil && !apierrors.IsNotFound(err) {
+				return admission.Errored(500, err), true
+			}
+			if err != nil || cr.Labels[guardianv1alpha1.LabelRoleCatalog] != "true" {
+				return admission.Denied(fmt.Sprintf(
+					"%s: clusterrole %q is not in the role catalog (tenant baseline.rbac.roleCatalog or label %s=true)",
+					path, b.Role, guardianv1alpha1.LabelRoleCatalog)), true
+			}
+		}
+
+		ok, err := v.canBind(ctx, b.Role)
+		if err != nil {
+			return admission.Errored(500, err), true
+		}
+		if !ok {
+			return admission.Denied(fmt.Sprintf(
+				"%s: namespace-guardian is not allowed to bind clusterrole %q, ask a platform admin to add it to the rbac-binder ClusterRole",
+				path, b.Role)), true
+		}
+	}
+	return admission.Response{}, false
+}
+
+// canBind：webhook 与 controller 跑在同一进程、同一 ServiceAccount 下，
+// 用 SelfSubjectAccessReview 问的就是 controller 自己能否 bind 这个 ClusterRole
+func (v *NamespaceRequestAuthzValidator) canBind(ctx context.Context, role string) (bool, error) {
+	ssar := &authorizationv1.SelfSubjectAccessReview{
+		Spec: authorizationv1.SelfSubjectAccessReviewSpec{
+			ResourceAttributes: &authorizationv1.ResourceAttributes{
+				Group:    rbacv1.GroupName,
+				Resource: "clusterroles",
+				Verb:     "bind",
+				Name:     role,
+			},
+		},
+	}
+	if err := v.Client.Create(ctx, ssar); err != nil {
+		return false, err
+	}
+	return ssar.Status.Allowed, nil
+}
+
+// isTransferAccept：接受移交 = ownerGroup 改成旧对象上的 transfer-to，且 transfer-to 被清掉
+func isTransferAccept(oldObj, newObj *guardianv1alpha1.NamespaceRequest) bool {
+	to := oldObj.Annotations[guardianv1alpha1.AnnTransferTo]
+	return to != "" && newObj.Spec.OwnerGroup == to && newObj.Annotations[guardianv1alpha1.AnnTransferTo] == ""
+}
+
+// validateOwnerGroup：返回拒绝原因，空串表示通过；create 的 spec.ownerGroup 与移交目标共用
+// 长度不超过 63，须在 Tenant allowedGroups 中或以 <tenant>: 开头，不能是 system: 开头的内置组（如 system:authenticated）
+func validateOwnerGroup(t *guardianv1alpha1.Tenant, group, what string) string {
+	switch {
+	case group == "":
+		return fmt.Sprintf("%s is required", what)
+	case len(group) > 63:
+		return fmt.Sprintf("%s too long (max 63)", what)
+	case strings.HasPrefix(group, "system:"):
+		return fmt.Sprintf("%s %q: system groups cannot own a namespace", what, group)
+	case !contains(t.Spec.AllowedGroups, group) && !strings.HasPrefix(group, t.Name+":"):
+		return fmt.Sprintf("%s %q must be in tenant %q allowedGroups or start with %q", what, group, t.Name, t.Name+":")
+	}
+	return ""
+}
+
+// validateTransferRequest：transfer-to 只能由当前 owner 设置/取消，transfer-requested-by 必须是本人，
+// 目标组与 create 时的 ownerGroup 同样校验
+func validateTransferRequest(req admission.Request, t *guardianv1alpha1.Tenant, oldObj, newObj *guardianv1alpha1.NamespaceRequest) (admission.Response, bool) {
+	oldTo := oldObj.Annotations[guardianv1alpha1.AnnTransferTo]
+	newTo := newObj.Annotations[guardianv1alpha1.AnnTransferTo]
+	by := newObj.Annotations[guardianv1alpha1.AnnTransferRequestedBy]
+
+	if newTo == oldTo {
+		if by != oldObj.Annotations[guardianv1alpha1.AnnTransferRequestedBy] {
+			return admission.Denied(fmt.Sprintf("annotation %s is written by the webhook only", guardianv1alpha1.AnnTransferRequestedBy)), true
+		}
+		return admission.Response{}, false
+	}
+	if newTo == "" {
+		// 取消移交
+		return admission.Response{}, false
+	}
+	if by != req.UserInfo.Username {
+		return admission.Denied(fmt.Sprintf("annotation %s must be the requesting user", guardianv1alpha1.AnnTransferRequestedBy)), true
+	}
+	if newTo == newObj.Spec.OwnerGroup {
+		return admission.Denied(fmt.Sprintf("annotation %s must differ from the current ownerGroup", guardianv1alpha1.AnnTransferTo)), true
+	}
+	if msg := validateOwnerGroup(t, newTo, "annotation "+guardianv1alpha1.AnnTransferTo); msg != "" {
+		return admission.Denied(msg), true
+	}
+	return admission.Response{}, false
+}
+
+// validateTransferAccept：接受人须属于新 ownerGroup 或 <tenant>:ns-admin，不能夹带其它 spec 修改，
+// 新 ownerGroup 在同 tenant/env 下也要满足“一组一个 ns”
+func (v *NamespaceRequestAuthzValidator) validateTransferAccept(ctx context.Context, req admission.Request, t *guardianv1alpha1.Tenant,
+	oldObj, newObj *guardianv1alpha1.NamespaceRequest) admission.Response {
+	user := req.UserInfo.Username
+	to := newObj.Spec.OwnerGroup
+	if newObj.Annotations[guardianv1alpha1.AnnTransferAcceptedBy] != user {
+		return admission.Denied(fmt.Sprintf("annotation %s must be the accepting user", guardianv1alpha1.AnnTransferAcceptedBy))
+	}
+	if newObj.Annotations[guardianv1alpha1.AnnTransferRequestedBy] != oldObj.Annotations[guardianv1alpha1.AnnTransferRequestedBy] {
+		return admission.Denied(fmt.Sprintf("annotation %s is written by the webhook only", guardianv1alpha1.AnnTransferRequestedBy))
+	}
+	if !anyGroupAllowed(req.UserInfo.Groups, t.Spec.AllowedGroups) {
+		return admission.Denied("forbidden: not allowed for this tenant")
+	}
+	if msg := validateOwnerGroup(t, to, "spec.ownerGroup"); msg != "" {
+		return admission.Denied(msg)
+	}
+	adminGroup := t.Name + ":ns-admin"
+	if !contains(req.UserInfo.Groups, to) && !contains(req.UserInfo.Groups, adminGroup) {
+		return admission.Denied(fmt.Sprintf(
+			"forbidden: accepting a transfer to %q requires that group or %q, got groups=%v", to, adminGroup, req.UserInfo.Groups,
+		))
+	}
+
+	rest := newObj.Spec.DeepCopy()
+	rest.OwnerGroup = oldObj.Spec.OwnerGroup
+	if !equality.Semantic.DeepEqual(oldObj.Spec, *rest) {
+		return admission.Denied("accepting a transfer cannot be combined with other spec changes")
+	}
+
+	env := strings.TrimSpace(newObj.Spec.Env)
+	if env == "" {
+		env = "dev"
+	}
+	var reqList guardianv1alpha1.NamespaceRequestList
+	sel := client.MatchingLabels{
+		guardianv1alpha1.LabelTenant:         t.Name,
+		guardianv1alpha1.LabelEnv:            env,
+		guardianv1alpha1.LabelOwnerGroupHash: guardianv1alpha1.ShortHash16(to),
+	}
+	if err := v.Client.List(ctx, &reqList, sel); err != nil {
+		return admission.Errored(500, err)
+	}
+	for i := range reqList.Items {
+		if exist := &reqList.Items[i]; exist.Name != newObj.Name && exist.Status.Phase != guardianv1alpha1.PhaseFailed {
+			return admission.Denied(fmt.Sprintf(
+				"ownerGroup %q already has a namespace request for tenant=%s env=%s (existing nsreq=%s)", to, t.Name, env, exist.Name,
+			))
+		}
+	}
+
+	namespacerequestlog.Info("OWNER_TRANSFER_ACCEPTED", "nsreq", newObj.Name, "from", oldObj.Spec.OwnerGroup, "to", to, "acceptedBy", user)
+	return admission.Allowed("transfer accepted")
+}
+
+// validateApproval：approved-by 只能在原列表末尾追加当前用户，且当前用户必须属于审批组；
+// 默认不允许申请人给自己审批，审批也不能夹带 spec 修改
+func validateApproval(req admission.Request, t *guardianv1alpha1.Tenant, oldObj, newObj *guardianv1alpha1.NamespaceRequest) admission.Response {
+	user := req.UserInfo.Username
+	oldList := guardianv1alpha1.ApprovedBy(oldObj)
+	want := strings.Join(append(oldList, user), ",")
+	if newObj.Annotations[guardianv1alpha1.AnnApprovedBy] != want {
+		return admission.Denied(fmt.Sprintf(
+			"annotation %s can only be changed by annotating %s=true (appends the approving user)",
+			guardianv1alpha1.AnnApprovedBy, guardianv1alpha1.AnnApprove,
+		))
+	}
+	if contains(oldList, user) {
+		return admission.Denied(fmt.Sprintf("user %q has already approved", user))
+	}
+	// 审批请求只能改 approved-by：移交、adopt-confirm、retry、TTL 延长等走不到下面的 owner/admin 校验
+	if !equality.Semantic.DeepEqual(oldObj.Spec, newObj.Spec) {
+		return admission.Denied("approval cannot be combined with spec changes")
+	}
+	oldAnn, newAnn := maps.Clone(oldObj.Annotations), maps.Clone(newObj.Annotations)
+	delete(oldAnn, guardianv1alpha1.AnnApprovedBy)
+	delete(newAnn, guardianv1alpha1.AnnApprovedBy)
+	if !maps.Equal(oldAnn, newAnn) || !maps.Equal(oldObj.Labels, newObj.Labels) {
+		return admission.Denied("approval cannot be combined with other label or annotation changes")
+	}
+
+	approvers := guardianv1alpha1.ApproverGroups(&t.Spec, t.Name)
+	if !anyGroupAllowed(req.UserInfo.Groups, approvers) {
+		return admission.Denied(fmt.Sprintf(
+			"forbidden: user=%q groups=%v cannot approve, need one of %v", user, req.UserInfo.Groups, approvers,
+		))
+	}
+	allowSelf := t.Spec.Approval != nil && t.Spec.Approval.AllowSelfApproval
+	if !allowSelf && user == oldObj.Annotations[guardianv1alpha1.AnnRequestedBy] {
+		return admission.Denied(fmt.Sprintf("forbidden: requester %q cannot approve their own request", user))
+	}
+
+	namespacerequestlog.Info("APPROVAL_RECORDED", "nsreq", newObj.Name, "approver", user, "approvedBy", want)
+	return admission.Allowed("approved")
+}
+
 func anyGroupAllowed(userGroups, allowed []string) bool {
 	if len(allowed) == 0 {
 		return false
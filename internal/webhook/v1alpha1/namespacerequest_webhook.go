@@ -2,12 +2,20 @@ package v1alpha1
 
 import (
 	"context"
+	"encoding/base64"
+	"encoding/json"
 	"fmt"
+	"os"
 	"strings"
+	"time"
 
 	guardianv1alpha1 "github.com/CATDOGME/namespace-guardian/api/v1alpha1"
 
+	admissionv1 "k8s.io/api/admission/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -21,6 +29,10 @@ var namespacerequestlog = logf.Log.WithName("namespacerequest-webhook")
 
 const (
 	ValidatePath = "/validate-guardian-guardian-io-v1alpha1-namespacerequest"
+
+	// controller 的 namespace 与 ServiceAccount（config/manager/manager.yaml 经 downward API 注入）
+	EnvPodNamespace       = "POD_NAMESPACE"
+	EnvServiceAccountName = "SERVICE_ACCOUNT_NAME"
 )
 
 // SetupNamespaceRequestWebhookWithManager registers the webhook for NamespaceRequest in the manager.
@@ -41,17 +53,68 @@ func SetupNamespaceRequestWebhookWithManager(mgr ctrl.Manager) error {
 
 	// 2) Validating/Authz：手动 Register 固定 validate path
 	// 放在 Complete() 之后，避免被 builder 生成的 handler 覆盖路由
+	// controller 与 webhook 同一进程：controller 的用户名由 Deployment 经 downward API 注入，启动时不访问 apiserver
+	// 拿不到身份时 finalizer 无法移除、删除会一直卡住，所以直接让启动失败
+	controllerUser, err := controllerUsername()
+	if err != nil {
+		return err
+	}
 	mgr.GetWebhookServer().Register(ValidatePath, &admission.Webhook{
 		Handler: &NamespaceRequestAuthzValidator{
-			Client:  c,
-			Decoder: dec,
+			Client:             c,
+			Decoder:            dec,
+			ControllerUsername: controllerUser,
 		},
 	})
 
-	namespacerequestlog.Info("authz validating webhook registered", "path", ValidatePath)
+	namespacerequestlog.Info("authz validating webhook registered", "path", ValidatePath, "controller", controllerUser)
 	return nil
 }
 
+// serviceAccountTokenFile：in-cluster ServiceAccount token，测试里替换
+var serviceAccountTokenFile = "/var/run/secrets/kubernetes.io/serviceaccount/token"
+
+// controllerUsername：system:serviceaccount:<POD_NAMESPACE>:<SERVICE_ACCOUNT_NAME>；
+// env 没注入时退回 in-cluster token 的 sub，两者都拿不到时返回错误
+func controllerUsername() (string, error) {
+	ns, sa := os.Getenv(EnvPodNamespace), os.Getenv(EnvServiceAccountName)
+	if ns != "" && sa != "" {
+		return fmt.Sprintf("system:serviceaccount:%s:%s", ns, sa), nil
+	}
+	sub, err := tokenSubject(serviceAccountTokenFile)
+	if err != nil {
+		return "", fmt.Errorf("controller identity unknown: set %s and %s, or run with a ServiceAccount token: %w",
+			EnvPodNamespace, EnvServiceAccountName, err)
+	}
+	return sub, nil
+}
+
+// tokenSubject：读取 ServiceAccount token（JWT）payload 里的 sub，只接受 system:serviceaccount: 身份
+func tokenSubject(path string) (string, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	parts := strings.Split(strings.TrimSpace(string(raw)), ".")
+	if len(parts) != 3 {
+		return "", fmt.Errorf("%s is not a JWT", path)
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return "", fmt.Errorf("decode token payload: %w", err)
+	}
+	var claims struct {
+		Sub string `json:"sub"`
+	}
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return "", fmt.Errorf("decode token claims: %w", err)
+	}
+	if !strings.HasPrefix(claims.Sub, "system:serviceaccount:") {
+		return "", fmt.Errorf("token subject %q is not a ServiceAccount", claims.Sub)
+	}
+	return claims.Sub, nil
+}
+
 // +kubebuilder:webhook:path=/mutate-guardian-guardian-io-v1alpha1-namespacerequest,mutating=true,failurePolicy=fail,sideEffects=None,groups=guardian.guardian.io,resources=namespacerequests,verbs=create;update,versions=v1alpha1,name=mnamespacerequest-v1alpha1.kb.io,admissionReviewVersions=v1
 
 // +kubebuilder:webhook:path=/validate-guardian-guardian-io-v1alpha1-namespacerequest,mutating=false,failurePolicy=fail,sideEffects=None,groups=guardian.guardian.io,resources=namespacerequests,verbs=create;update,versions=v1alpha1,name=vnamespacerequest-v1alpha1.kb.io,admissionReviewVersions=v1
@@ -64,7 +127,7 @@ type NamespaceRequestCustomDefaulter struct {
 var _ webhook.CustomDefaulter = &NamespaceRequestCustomDefaulter{}
 
 // Default implements webhook.CustomDefaulter.
-func (d *NamespaceRequestCustomDefaulter) Default(_ context.Context, obj runtime.Object) error {
+func (d *NamespaceRequestCustomDefaulter) Default(ctx context.Context, obj runtime.Object) error {
 	nr, ok := obj.(*guardianv1alpha1.NamespaceRequest)
 	if !ok {
 		return fmt.Errorf("expected NamespaceRequest but got %T", obj)
@@ -74,12 +137,25 @@ func (d *NamespaceRequestCustomDefaulter) Default(_ context.Context, obj runtime
 	nr.Spec.Tenant = strings.TrimSpace(nr.Spec.Tenant)
 	nr.Spec.Env = strings.TrimSpace(nr.Spec.Env)
 	nr.Spec.OwnerGroup = strings.TrimSpace(nr.Spec.OwnerGroup)
+	for i := range nr.Spec.ExtraBindings {
+		b := &nr.Spec.ExtraBindings[i]
+		b.Name = strings.TrimSpace(b.Name)
+		b.Namespace = strings.TrimSpace(b.Namespace)
+		b.Role = strings.TrimSpace(b.Role)
+	}
 
 	// env default
 	if nr.Spec.Env == "" {
 		nr.Spec.Env = "dev"
 	}
 
+	// 身份/审批/移交注解只能来自 admission 请求里的 UserInfo，不信任用户 YAML 里的值
+	// 放在 labels 之前：接受移交会改写 spec.ownerGroup
+	req, reqErr := admission.RequestFromContext(ctx)
+	if reqErr == nil {
+		defaultIdentityAnnotations(nr, req)
+	}
+
 	// labels（用于 selector，避免全量扫描）
 	if nr.Labels == nil {
 		nr.Labels = map[string]string{}
@@ -90,5 +166,140 @@ func (d *NamespaceRequestCustomDefaulter) Default(_ context.Context, obj runtime
 	nr.Labels[guardianv1alpha1.LabelOwnerGroupHash] = guardianv1alpha1.ShortHash16(nr.Spec.OwnerGroup)
 	nr.Labels[guardianv1alpha1.LabelManaged] = "true"
 
+	// tenant 不存在交给 validator 拒绝
+	var t *guardianv1alpha1.Tenant
+	if d.Client != nil && nr.Spec.Tenant != "" {
+		var tenant guardianv1alpha1.Tenant
+		if err := d.Client.Get(ctx, types.NamespacedName{Name: nr.Spec.Tenant}, &tenant); err == nil {
+			t = &tenant
+		} else if !apierrors.IsNotFound(err) {
+			return err
+		}
+	}
+
+	// namespaceName：按 Tenant 命名策略计算并回填，用户 apply 后即可看到最终名称
+	// 已 Provisioned 的老对象沿用 status 里的名称，避免和实际 namespace 不一致；
+	// update 时不再按模板回填（validator 只放行按 status 回填的名称，其余由 controller 计算）
+	updating := reqErr == nil && req.Operation == admissionv1.Update
+	nr.Spec.NamespaceName = strings.TrimSpace(nr.Spec.NamespaceName)
+	if nr.Spec.NamespaceName == "" {
+		if nr.Status.NamespaceName != "" {
+			nr.Spec.NamespaceName = nr.Status.NamespaceName
+		} else if t != nil && !updating {
+			nr.Spec.NamespaceName = guardianv1alpha1.NamespaceNameFor(&t.Spec, nr)
+		}
+	}
+
+	// ttl：创建时按 Tenant defaultByEnv 补默认值；extend-ttl 注解换算成新的 spec.ttl
+	if nr.Spec.TTL == nil && t != nil && reqErr == nil && req.Operation == admissionv1.Create {
+		nr.Spec.TTL = guardianv1alpha1.DefaultTTL(&t.Spec, nr.Spec.Env)
+	}
+	applyTTLExtension(nr, time.Now())
+
 	return nil
 }
+
+// applyTTLExtension：新到期时间 = max(当前到期时间, now) + 注解里的时长，再换算回从创建时间起算的 spec.ttl；
+// 注解解析失败时保留原样，交给 validator 拒绝；上限与 owner 身份也由 validator 按普通 update 校验
+func applyTTLExtension(nr *guardianv1alpha1.NamespaceRequest, now time.Time) {
+	raw, ok := nr.Annotations[guardianv1alpha1.AnnExtendTTL]
+	if !ok {
+		return
+	}
+	d, err := time.ParseDuration(strings.TrimSpace(raw))
+	if err != nil || d <= 0 {
+		return
+	}
+
+	base := now
+	if exp := guardianv1alpha1.RequestExpiresAt(nr); exp != nil && exp.After(now) {
+		base = exp.Time
+	}
+	created := nr.CreationTimestamp.Time
+	if created.IsZero() {
+		created = now
+	}
+	nr.Spec.TTL = &metav1.Duration{Duration: base.Add(d).Sub(created).Round(time.Second)}
+	delete(nr.Annotations, guardianv1alpha1.AnnExtendTTL)
+}
+
+// defaultIdentityAnnotations：
+// - create：requested-by/requested-by-groups/request-uid/requested-at 按本次 admission 请求写入，清掉任何自带的审批/移交记录
+// - update：身份注解一律恢复成旧对象上的值；审批人打上 guardian.io/approve=true 时，换成把自己的 username 追加到 approved-by
+// 是否有权审批由 validating webhook 判断（同一个 UserInfo）
+func defaultIdentityAnnotations(nr *guardianv1alpha1.NamespaceRequest, req admission.Request) {
+	if nr.Annotations == nil {
+		nr.Annotations = map[string]string{}
+	}
+	user := req.UserInfo.Username
+
+	switch req.Operation {
+	case admissionv1.Create:
+		nr.Annotations[guardianv1alpha1.AnnRequestedBy] = user
+		nr.Annotations[guardianv1alpha1.AnnRequestedByGroups] = strings.Join(req.UserInfo.Groups, ",")
+		nr.Annotations[guardianv1alpha1.AnnRequestUID] = string(req.UID)
+		nr.Annotations[guardianv1alpha1.AnnRequestedAt] = time.Now().UTC().Format(time.RFC3339)
+		for _, k := range []string{
+			guardianv1alpha1.AnnApprovedBy, guardianv1alpha1.AnnApprove,
+			guardianv1alpha1.AnnTransferTo, guardianv1alpha1.AnnTransferAccept,
+			guardianv1alpha1.AnnTransferRequestedBy, guardianv1alpha1.AnnTransferAcceptedBy,
+		} {
+			delete(nr.Annotations, k)
+		}
+	case admissionv1.Update:
+		old := &guardianv1alpha1.NamespaceRequest{}
+		if len(req.OldObject.Raw) > 0 {
+			if err := json.Unmarshal(req.OldObject.Raw, old); err != nil {
+				namespacerequestlog.Error(err, "decode old object failed", "nsreq", nr.Name)
+			} else {
+				restoreRequesterAnnotations(nr, old)
+			}
+		}
+		defaultTransferAnnotations(nr, old, user)
+
+		if _, ok := nr.Annotations[guardianv1alpha1.AnnApprove]; !ok {
+			return
+		}
+		delete(nr.Annotations, guardianv1alpha1.AnnApprove)
+		approvers := guardianv1alpha1.ApprovedBy(nr)
+		if !contains(approvers, user) {
+			approvers = append(approvers, user)
+		}
+		nr.Annotations[guardianv1alpha1.AnnApprovedBy] = strings.Join(approvers, ",")
+	}
+}
+
+// defaultTransferAnnotations：
+// - 发起：transfer-to 变化时把发起人记到 transfer-requested-by，清掉上一次的接受人
+// - 接受：打上 transfer-accept=true 时把 spec.ownerGroup 改成 transfer-to，记录接受人并结束本次移交
+// 发起人/接受人是否有权由 validating webhook 判断
+func defaultTransferAnnotations(nr, old *guardianv1alpha1.NamespaceRequest, user string) {
+	to := strings.TrimSpace(nr.Annotations[guardianv1alpha1.AnnTransferTo])
+	if to != "" && to != old.Annotations[guardianv1alpha1.AnnTransferTo] {
+		nr.Annotations[guardianv1alpha1.AnnTransferTo] = to
+		nr.Annotations[guardianv1alpha1.AnnTransferRequestedBy] = user
+		delete(nr.Annotations, guardianv1alpha1.AnnTransferAcceptedBy)
+	}
+
+	if _, ok := nr.Annotations[guardianv1alpha1.AnnTransferAccept]; !ok {
+		return
+	}
+	delete(nr.Annotations, guardianv1alpha1.AnnTransferAccept)
+	if to == "" {
+		return
+	}
+	nr.Spec.OwnerGroup = to
+	nr.Annotations[guardianv1alpha1.AnnTransferAcceptedBy] = user
+	delete(nr.Annotations, guardianv1alpha1.AnnTransferTo)
+}
+
+// restoreRequesterAnnotations：身份注解只在 create 时写入，update 时改动/删除/新增都还原成旧对象上的状态
+func restoreRequesterAnnotations(nr, old *guardianv1alpha1.NamespaceRequest) {
+	for _, k := range guardianv1alpha1.RequesterAnnotationKeys {
+		if v, ok := old.Annotations[k]; ok {
+			nr.Annotations[k] = v
+		} else {
+			delete(nr.Annotations, k)
+		}
+	}
+}
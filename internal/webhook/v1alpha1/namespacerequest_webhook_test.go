@@ -17,9 +17,26 @@ limitations under the License.
 package v1alpha1
 
 import (
+	"encoding/base64"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"time"
+
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
+	admissionv1 "k8s.io/api/admission/v1"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
 	guardianv1alpha1 "github.com/CATDOGME/namespace-guardian/api/v1alpha1"
 	// TODO (user): Add any additional imports if needed
 )
@@ -28,14 +45,14 @@ var _ = Describe("NamespaceRequest Webhook", func() {
 	var (
 		obj       *guardianv1alpha1.NamespaceRequest
 		oldObj    *guardianv1alpha1.NamespaceRequest
-		validator NamespaceRequestCustomValidator
+		validator NamespaceRequestAuthzValidator
 		defaulter NamespaceRequestCustomDefaulter
 	)
 
 	BeforeEach(func() {
 		obj = &guardianv1alpha1.NamespaceRequest{}
 		oldObj = &guardianv1alpha1.NamespaceRequest{}
-		validator = NamespaceRequestCustomValidator{}
+		validator = NamespaceRequestAuthzValidator{}
 		Expect(validator).NotTo(BeNil(), "Expected validator to be initialized")
 		defaulter = NamespaceRequestCustomDefaulter{}
 		Expect(defaulter).NotTo(BeNil(), "Expected defaulter to be initialized")
@@ -49,39 +66,527 @@ var _ = Describe("NamespaceRequest Webhook", func() {
 	})
 
 	Context("When creating NamespaceRequest under Defaulting Webhook", func() {
-		// TODO (user): Add logic for defaulting webhooks
-		// Example:
-		// It("Should apply defaults when a required field is empty", func() {
-		//     By("simulating a scenario where defaults should be applied")
-		//     obj.SomeFieldWithDefault = ""
-		//     By("calling the Default method to apply defaults")
-		//     defaulter.Default(ctx, obj)
-		//     By("checking that the default values are set")
-		//     Expect(obj.SomeFieldWithDefault).To(Equal("default_value"))
-		// })
+		It("Should compute spec.namespaceName from the Tenant naming template", func() {
+			By("creating a tenant with a naming template")
+			t := &guardianv1alpha1.Tenant{
+				ObjectMeta: metav1.ObjectMeta{Name: "naming-tenant"},
+				Spec: guardianv1alpha1.TenantSpec{
+					AllowedGroups: []string{"naming-tenant:dev"},
+					Naming:        &guardianv1alpha1.TenantNamingSpec{Template: "{tenant}-{env}-{group}"},
+				},
+			}
+			Expect(k8sClient.Create(ctx, t)).To(Succeed())
+			DeferCleanup(func() { Expect(k8sClient.Delete(ctx, t)).To(Succeed()) })
+
+			defaulter = NamespaceRequestCustomDefaulter{Client: k8sClient}
+			obj.Spec = guardianv1alpha1.NamespaceRequestSpec{
+				Tenant:     " naming-tenant ",
+				OwnerGroup: "naming-tenant:Team_A",
+			}
+			Expect(defaulter.Default(ctx, obj)).To(Succeed())
+			Expect(obj.Spec.Env).To(Equal("dev"))
+			Expect(obj.Spec.NamespaceName).To(Equal("naming-tenant-dev-team-a"))
+		})
+
+		It("Should keep the provisioned namespace name for existing requests", func() {
+			defaulter = NamespaceRequestCustomDefaulter{Client: k8sClient}
+			obj.Spec = guardianv1alpha1.NamespaceRequestSpec{Tenant: "missing", OwnerGroup: "missing:dev"}
+			obj.Status.NamespaceName = "missing-dev"
+			Expect(defaulter.Default(ctx, obj)).To(Succeed())
+			Expect(obj.Spec.NamespaceName).To(Equal("missing-dev"))
+		})
+	})
+
+	Context("When recording the requester identity", func() {
+		create := admission.Request{AdmissionRequest: admissionv1.AdmissionRequest{
+			UID:       types.UID("3f6c1c9e-req"),
+			Operation: admissionv1.Create,
+			UserInfo:  authenticationv1.UserInfo{Username: "alice", Groups: []string{"tenant-a:dev", "system:authenticated"}},
+		}}
+
+		It("Should stamp user, groups, admission UID and time on create", func() {
+			obj.Annotations = map[string]string{guardianv1alpha1.AnnRequestedBy: "mallory"}
+			defaultIdentityAnnotations(obj, create)
+			Expect(obj.Annotations).To(HaveKeyWithValue(guardianv1alpha1.AnnRequestedBy, "alice"))
+			Expect(obj.Annotations).To(HaveKeyWithValue(guardianv1alpha1.AnnRequestedByGroups, "tenant-a:dev,system:authenticated"))
+			Expect(obj.Annotations).To(HaveKeyWithValue(guardianv1alpha1.AnnRequestUID, "3f6c1c9e-req"))
+			_, err := time.Parse(time.RFC3339, obj.Annotations[guardianv1alpha1.AnnRequestedAt])
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("Should restore identity annotations on update", func() {
+			defaultIdentityAnnotations(oldObj, create)
+			raw, err := json.Marshal(oldObj)
+			Expect(err).NotTo(HaveOccurred())
+
+			edited := oldObj.DeepCopy()
+			edited.Annotations[guardianv1alpha1.AnnRequestedByGroups] = "tenant-a:ns-admin"
+			delete(edited.Annotations, guardianv1alpha1.AnnRequestUID)
+			defaultIdentityAnnotations(edited, admission.Request{AdmissionRequest: admissionv1.AdmissionRequest{
+				Operation: admissionv1.Update,
+				UserInfo:  authenticationv1.UserInfo{Username: "mallory"},
+				OldObject: runtime.RawExtension{Raw: raw},
+			}})
+			for _, k := range guardianv1alpha1.RequesterAnnotationKeys {
+				Expect(edited.Annotations).To(HaveKeyWithValue(k, oldObj.Annotations[k]))
+			}
+		})
+	})
+
+	Context("When requesting extra bindings", func() {
+		var t *guardianv1alpha1.Tenant
+
+		BeforeEach(func() {
+			validator = NamespaceRequestAuthzValidator{Client: k8sClient}
+			t = &guardianv1alpha1.Tenant{
+				ObjectMeta: metav1.ObjectMeta{Name: "tenant-a"},
+				Spec: guardianv1alpha1.TenantSpec{
+					AllowedGroups: []string{"tenant-a:dev", "tenant-a:qa"},
+					Baseline: &guardianv1alpha1.TenantBaselineSpec{
+						RBAC: &guardianv1alpha1.TenantRBACSpec{RoleCatalog: []string{"view"}},
+					},
+				},
+			}
+			obj.Spec = guardianv1alpha1.NamespaceRequestSpec{Tenant: "tenant-a", Env: "dev", OwnerGroup: "tenant-a:dev"}
+		})
+
+		check := func(bindings ...guardianv1alpha1.ExtraBinding) bool {
+			obj.Spec.ExtraBindings = bindings
+			_, denied := validator.validateExtraBindings(ctx, t, obj, "tenant-a-dev-dev")
+			return denied
+		}
+
+		It("Should allow catalog roles for allowed groups and local service accounts", func() {
+			Expect(check(
+				guardianv1alpha1.ExtraBinding{Kind: "Group", Name: "tenant-a:qa", Role: "view"},
+				guardianv1alpha1.ExtraBinding{Kind: "ServiceAccount", Name: "ci", Role: "view"},
+			)).To(BeFalse())
+		})
+
+		It("Should deny groups outside allowedGroups", func() {
+			Expect(check(guardianv1alpha1.ExtraBinding{Kind: "Group", Name: "tenant-b:dev", Role: "view"})).To(BeTrue())
+		})
+
+		It("Should deny service accounts from namespaces outside the tenant", func() {
+			Expect(check(guardianv1alpha1.ExtraBinding{Kind: "ServiceAccount", Name: "ci", Namespace: "kube-system", Role: "view"})).To(BeTrue())
+		})
+
+		It("Should only allow cluster roles labeled into the global catalog", func() {
+			Expect(check(guardianv1alpha1.ExtraBinding{Kind: "Group", Name: "tenant-a:qa", Role: "catalog-reader"})).To(BeTrue())
+
+			cr := &rbacv1.ClusterRole{ObjectMeta: metav1.ObjectMeta{
+				Name:   "catalog-reader",
+				Labels: map[string]string{guardianv1alpha1.LabelRoleCatalog: "true"},
+			}}
+			Expect(k8sClient.Create(ctx, cr)).To(Succeed())
+			DeferCleanup(func() { Expect(k8sClient.Delete(ctx, cr)).To(Succeed()) })
+			Expect(check(guardianv1alpha1.ExtraBinding{Kind: "Group", Name: "tenant-a:qa", Role: "catalog-reader"})).To(BeFalse())
+		})
+
+		It("Should deny catalog roles the controller is not allowed to bind", func() {
+			const user = "system:serviceaccount:default:binder-test"
+			cr := &rbacv1.ClusterRole{
+				ObjectMeta: metav1.ObjectMeta{Name: "binder-test"},
+				Rules: []rbacv1.PolicyRule{
+					{APIGroups: []string{rbacv1.GroupName}, Resources: []string{"clusterroles"}, Verbs: []string{"get"}},
+					{APIGroups: []string{rbacv1.GroupName}, Resources: []string{"clusterroles"}, Verbs: []string{"bind"},
+						ResourceNames: []string{"view"}},
+				},
+			}
+			crb := &rbacv1.ClusterRoleBinding{
+				ObjectMeta: metav1.ObjectMeta{Name: "binder-test"},
+				RoleRef:    rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "ClusterRole", Name: cr.Name},
+				Subjects:   []rbacv1.Subject{{Kind: rbacv1.UserKind, APIGroup: rbacv1.GroupName, Name: user}},
+			}
+			labeled := &rbacv1.ClusterRole{ObjectMeta: metav1.ObjectMeta{
+				Name:   "catalog-unbindable",
+				Labels: map[string]string{guardianv1alpha1.LabelRoleCatalog: "true"},
+			}}
+			for _, o := range []client.Object{cr, crb, labeled} {
+				Expect(k8sClient.Create(ctx, o)).To(Succeed())
+				DeferCleanup(func() { Expect(k8sClient.Delete(ctx, o)).To(Succeed()) })
+			}
+
+			// 模拟 controller 的 ServiceAccount：只能 bind view
+			impCfg := rest.CopyConfig(cfg)
+			impCfg.Impersonate = rest.ImpersonationConfig{UserName: user}
+			c, err := client.New(impCfg, client.Options{Scheme: k8sClient.Scheme()})
+			Expect(err).NotTo(HaveOccurred())
+			validator = NamespaceRequestAuthzValidator{Client: c}
+
+			Eventually(func() bool {
+				return check(guardianv1alpha1.ExtraBinding{Kind: "Group", Name: "tenant-a:qa", Role: "view"})
+			}).Should(BeFalse())
+			Expect(check(guardianv1alpha1.ExtraBinding{Kind: "Group", Name: "tenant-a:qa", Role: "catalog-unbindable"})).To(BeTrue())
+		})
+	})
+
+	Context("When validating a new NamespaceRequest", func() {
+		user := authenticationv1.UserInfo{Username: "alice", Groups: []string{"create-tenant:dev", "create-tenant:ns-admin"}}
+		create := func() admission.Response {
+			raw, err := json.Marshal(obj)
+			Expect(err).NotTo(HaveOccurred())
+			return validator.Handle(ctx, admission.Request{AdmissionRequest: admissionv1.AdmissionRequest{
+				Operation: admissionv1.Create,
+				UserInfo:  user,
+				Object:    runtime.RawExtension{Raw: raw},
+			}})
+		}
+		createTenant := func(spec guardianv1alpha1.TenantSpec) *guardianv1alpha1.Tenant {
+			spec.AllowedGroups = []string{"create-tenant:dev"}
+			t := &guardianv1alpha1.Tenant{ObjectMeta: metav1.ObjectMeta{Name: "create-tenant"}, Spec: spec}
+			Expect(k8sClient.Create(ctx, t)).To(Succeed())
+			DeferCleanup(func() { Expect(k8sClient.Delete(ctx, t)).To(Succeed()) })
+			return t
+		}
+
+		BeforeEach(func() {
+			validator = NamespaceRequestAuthzValidator{Client: k8sClient, Decoder: admission.NewDecoder(k8sClient.Scheme())}
+			obj.Name = "create-check"
+			obj.Spec = guardianv1alpha1.NamespaceRequestSpec{Tenant: "create-tenant", Env: "dev", OwnerGroup: "create-tenant:dev"}
+		})
+
+		It("Should deny creation while the Tenant is suspended with suspendDenyCreate", func() {
+			t := createTenant(guardianv1alpha1.TenantSpec{Suspend: true, SuspendDenyCreate: true})
+			resp := create()
+			Expect(resp.Allowed).To(BeFalse())
+			Expect(resp.Result.Message).To(ContainSubstring("suspended"))
+
+			By("accepting requests again when only the reconcile is suspended")
+			t.Spec.SuspendDenyCreate = false
+			Expect(k8sClient.Update(ctx, t)).To(Succeed())
+			Expect(create().Allowed).To(BeTrue())
+		})
+
+		It("Should only accept sizes the Tenant offers for the env", func() {
+			createTenant(guardianv1alpha1.TenantSpec{Baseline: &guardianv1alpha1.TenantBaselineSpec{
+				Quota: &guardianv1alpha1.TenantQuotaSpec{
+					Sizes: map[string]map[string]guardianv1alpha1.QuotaHard{"dev": {"small": {Pods: "10"}}},
+				},
+			}})
+			obj.Spec.Size = "large"
+			resp := create()
+			Expect(resp.Allowed).To(BeFalse())
+			Expect(resp.Result.Message).To(ContainSubstring("not offered"))
+
+			obj.Spec.Size = "small"
+			Expect(create().Allowed).To(BeTrue())
+		})
+
+		It("Should only adopt namespaces guardian does not know yet", func() {
+			createTenant(guardianv1alpha1.TenantSpec{AllowAdoption: true})
+			for name, labels := range map[string]map[string]string{
+				"adopt-managed":  {guardianv1alpha1.LabelManaged: "true"},
+				"adopt-orphaned": {guardianv1alpha1.LabelOrphaned: "true"},
+				"adopt-handmade": nil,
+			} {
+				ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels}}
+				Expect(k8sClient.Create(ctx, ns)).To(Succeed())
+				DeferCleanup(func() { Expect(k8sClient.Delete(ctx, ns)).To(Succeed()) })
+			}
+
+			for _, name := range []string{"adopt-managed", "adopt-orphaned"} {
+				obj.Spec.Adopt = name
+				resp := create()
+				Expect(resp.Allowed).To(BeFalse(), name)
+				Expect(resp.Result.Message).To(ContainSubstring("already known to guardian"), name)
+			}
+
+			obj.Spec.Adopt = "adopt-handmade"
+			Expect(create().Allowed).To(BeTrue())
+		})
 	})
 
-	Context("When creating or updating NamespaceRequest under Validating Webhook", func() {
-		// TODO (user): Add logic for validating webhooks
-		// Example:
-		// It("Should deny creation if a required field is missing", func() {
-		//     By("simulating an invalid creation scenario")
-		//     obj.SomeRequiredField = ""
-		//     Expect(validator.ValidateCreate(ctx, obj)).Error().To(HaveOccurred())
-		// })
-		//
-		// It("Should admit creation if all required fields are present", func() {
-		//     By("simulating an invalid creation scenario")
-		//     obj.SomeRequiredField = "valid_value"
-		//     Expect(validator.ValidateCreate(ctx, obj)).To(BeNil())
-		// })
-		//
-		// It("Should validate updates correctly", func() {
-		//     By("simulating a valid update scenario")
-		//     oldObj.SomeRequiredField = "updated_value"
-		//     obj.SomeRequiredField = "updated_value"
-		//     Expect(validator.ValidateUpdate(ctx, oldObj, obj)).To(BeNil())
-		// })
+	Context("When the controller updates request metadata", func() {
+		sa := authenticationv1.UserInfo{
+			Username: "system:serviceaccount:namespace-guardian-system:namespace-guardian-controller-manager",
+			Groups:   []string{"system:serviceaccounts", "system:serviceaccounts:namespace-guardian-system", "system:authenticated"},
+		}
+		updateAs := func(user authenticationv1.UserInfo, newObj *guardianv1alpha1.NamespaceRequest) admission.Response {
+			oldRaw, err := json.Marshal(oldObj)
+			Expect(err).NotTo(HaveOccurred())
+			newRaw, err := json.Marshal(newObj)
+			Expect(err).NotTo(HaveOccurred())
+			validator = NamespaceRequestAuthzValidator{Client: k8sClient, Decoder: admission.NewDecoder(k8sClient.Scheme()),
+				ControllerUsername: sa.Username}
+			return validator.Handle(ctx, admission.Request{AdmissionRequest: admissionv1.AdmissionRequest{
+				Operation: admissionv1.Update,
+				UserInfo:  user,
+				Object:    runtime.RawExtension{Raw: newRaw},
+				OldObject: runtime.RawExtension{Raw: oldRaw},
+			}})
+		}
+		update := func(newObj *guardianv1alpha1.NamespaceRequest) admission.Response { return updateAs(sa, newObj) }
+
+		BeforeEach(func() {
+			oldObj.Name = "sa-update"
+			oldObj.Spec = guardianv1alpha1.NamespaceRequestSpec{Tenant: "tenant-a", Env: "dev", OwnerGroup: "tenant-a:dev"}
+			oldObj.Annotations = map[string]string{guardianv1alpha1.AnnRequestedBy: "alice", guardianv1alpha1.AnnRetry: "now"}
+		})
+
+		It("Should allow adding/removing the finalizer and consuming guardian.io/retry", func() {
+			newObj := oldObj.DeepCopy()
+			newObj.Finalizers = []string{guardianv1alpha1.FinalizerNamespaceRequest}
+			delete(newObj.Annotations, guardianv1alpha1.AnnRetry)
+			Expect(update(newObj).Allowed).To(BeTrue())
+
+			oldObj = newObj.DeepCopy()
+			newObj.Finalizers = nil
+			Expect(update(newObj).Allowed).To(BeTrue())
+		})
+
+		It("Should take the controller identity from the downward API env", func() {
+			tokenFile := serviceAccountTokenFile
+			DeferCleanup(func() { serviceAccountTokenFile = tokenFile })
+			serviceAccountTokenFile = filepath.Join(GinkgoT().TempDir(), "token")
+
+			By("refusing to start without any identity")
+			GinkgoT().Setenv(EnvPodNamespace, "")
+			GinkgoT().Setenv(EnvServiceAccountName, "")
+			_, err := controllerUsername()
+			Expect(err).To(HaveOccurred())
+
+			By("falling back to the in-cluster ServiceAccount token")
+			claims := base64.RawURLEncoding.EncodeToString([]byte(`{"sub":"` + sa.Username + `"}`))
+			Expect(os.WriteFile(serviceAccountTokenFile, []byte("e30."+claims+".sig"), 0o600)).To(Succeed())
+			Expect(controllerUsername()).To(Equal(sa.Username))
+
+			By("preferring the downward API env")
+			GinkgoT().Setenv(EnvPodNamespace, "other-system")
+			GinkgoT().Setenv(EnvServiceAccountName, "other-manager")
+			Expect(controllerUsername()).To(Equal("system:serviceaccount:other-system:other-manager"))
+		})
+
+		It("Should only let the controller remove the reclaim finalizer", func() {
+			oldObj.Finalizers = []string{guardianv1alpha1.FinalizerNamespaceRequest}
+			newObj := oldObj.DeepCopy()
+			newObj.Finalizers = nil
+			for _, user := range []authenticationv1.UserInfo{
+				{Username: "alice", Groups: []string{"tenant-a:dev"}},
+				{Username: "bob", Groups: []string{"tenant-a:ns-admin"}},
+				{Username: "system:serviceaccount:kube-system:generic-garbage-collector"},
+			} {
+				Expect(updateAs(user, newObj).Allowed).To(BeFalse(), user.Username)
+			}
+			Expect(update(newObj).Allowed).To(BeTrue())
+		})
+
+		It("Should run the owner checks for metadata-only updates from anyone else", func() {
+			newObj := oldObj.DeepCopy()
+			newObj.Finalizers = []string{guardianv1alpha1.FinalizerNamespaceRequest}
+			Expect(updateAs(authenticationv1.UserInfo{Username: "mallory"}, newObj).Allowed).To(BeFalse())
+		})
+
+		It("Should only bypass for guardian's finalizer and the defaulted namespaceName and labels", func() {
+			By("denying foreign finalizers")
+			newObj := oldObj.DeepCopy()
+			newObj.Finalizers = []string{"example.com/keep"}
+			Expect(update(newObj).Allowed).To(BeFalse())
+
+			By("denying a namespaceName other than the one in status")
+			oldObj.Status.NamespaceName = "tenant-a-dev-dev"
+			newObj = oldObj.DeepCopy()
+			newObj.Spec.NamespaceName = "kube-system"
+			Expect(update(newObj).Allowed).To(BeFalse())
+			newObj.Spec.NamespaceName = oldObj.Status.NamespaceName
+			Expect(update(newObj).Allowed).To(BeTrue())
+
+			By("denying selector labels that do not match the spec")
+			newObj = oldObj.DeepCopy()
+			newObj.Labels = map[string]string{guardianv1alpha1.LabelTenant: "tenant-a", guardianv1alpha1.LabelEnv: "dev"}
+			Expect(update(newObj).Allowed).To(BeTrue())
+			newObj.Labels[guardianv1alpha1.LabelTenant] = "tenant-b"
+			Expect(update(newObj).Allowed).To(BeFalse())
+		})
+
+		It("Should let the garbage collector remove its own finalizer during deletion", func() {
+			oldObj.DeletionTimestamp = &metav1.Time{Time: time.Now()}
+			oldObj.Finalizers = []string{metav1.FinalizerDeleteDependents, guardianv1alpha1.FinalizerNamespaceRequest}
+			newObj := oldObj.DeepCopy()
+			newObj.Finalizers = []string{guardianv1alpha1.FinalizerNamespaceRequest}
+			gc := authenticationv1.UserInfo{Username: "system:serviceaccount:kube-system:generic-garbage-collector"}
+			Expect(updateAs(gc, newObj).Allowed).To(BeTrue())
+		})
+
+		It("Should still run the owner checks when anything else changes", func() {
+			newObj := oldObj.DeepCopy()
+			newObj.Finalizers = []string{guardianv1alpha1.FinalizerNamespaceRequest}
+			newObj.Annotations["example.com/note"] = "x"
+			Expect(update(newObj).Allowed).To(BeFalse())
+
+			newObj = oldObj.DeepCopy()
+			newObj.Finalizers = []string{guardianv1alpha1.FinalizerNamespaceRequest}
+			newObj.Spec.Size = "large"
+			Expect(update(newObj).Allowed).To(BeFalse())
+
+			newObj = oldObj.DeepCopy()
+			newObj.Annotations[guardianv1alpha1.AnnTransferTo] = "tenant-a:qa"
+			Expect(update(newObj).Allowed).To(BeFalse())
+		})
+	})
+
+	Context("When approving a NamespaceRequest", func() {
+		var t *guardianv1alpha1.Tenant
+		approve := func(user string, groups ...string) admission.Response {
+			req := admission.Request{AdmissionRequest: admissionv1.AdmissionRequest{
+				Operation: admissionv1.Update,
+				UserInfo:  authenticationv1.UserInfo{Username: user, Groups: groups},
+			}}
+			newObj := oldObj.DeepCopy()
+			newObj.Annotations[guardianv1alpha1.AnnApprove] = "true"
+			defaultIdentityAnnotations(newObj, req)
+			return validateApproval(req, t, oldObj, newObj)
+		}
+
+		BeforeEach(func() {
+			t = &guardianv1alpha1.Tenant{
+				ObjectMeta: metav1.ObjectMeta{Name: "tenant-a"},
+				Spec: guardianv1alpha1.TenantSpec{
+					Approval: &guardianv1alpha1.TenantApprovalSpec{Envs: []string{"prod"}},
+				},
+			}
+			oldObj.Annotations = map[string]string{guardianv1alpha1.AnnRequestedBy: "alice"}
+		})
+
+		It("Should record an approval from a tenant admin", func() {
+			Expect(approve("bob", "tenant-a:ns-admin").Allowed).To(BeTrue())
+		})
+
+		It("Should deny approvals from outside the approver groups", func() {
+			Expect(approve("carol", "tenant-a:prod").Allowed).To(BeFalse())
+		})
+
+		It("Should deny self-approval by the requester", func() {
+			Expect(approve("alice", "tenant-a:ns-admin").Allowed).To(BeFalse())
+		})
+
+		It("Should deny approvals combined with other changes", func() {
+			for _, k := range []string{
+				"example.com/note", guardianv1alpha1.AnnTransferTo, guardianv1alpha1.AnnAdoptConfirm,
+				guardianv1alpha1.AnnRetry, guardianv1alpha1.AnnExtendTTL,
+			} {
+				req := admission.Request{AdmissionRequest: admissionv1.AdmissionRequest{Operation: admissionv1.Update}}
+				req.UserInfo = authenticationv1.UserInfo{Username: "bob", Groups: []string{"tenant-a:ns-admin"}}
+				newObj := oldObj.DeepCopy()
+				newObj.Annotations[guardianv1alpha1.AnnApprove] = "true"
+				newObj.Annotations[k] = "bob"
+				defaultIdentityAnnotations(newObj, req)
+				Expect(validateApproval(req, t, oldObj, newObj).Allowed).To(BeFalse(), k)
+			}
+		})
+
+		It("Should deny forged approved-by annotations", func() {
+			newObj := oldObj.DeepCopy()
+			newObj.Annotations[guardianv1alpha1.AnnApprovedBy] = "bob"
+			req := admission.Request{}
+			req.UserInfo = authenticationv1.UserInfo{Username: "mallory", Groups: []string{"tenant-a:ns-admin"}}
+			Expect(validateApproval(req, t, oldObj, newObj).Allowed).To(BeFalse())
+		})
+	})
+
+	Context("When transferring the owner group", func() {
+		t := &guardianv1alpha1.Tenant{
+			ObjectMeta: metav1.ObjectMeta{Name: "tenant-a"},
+			Spec:       guardianv1alpha1.TenantSpec{AllowedGroups: []string{"team-a", "team-b"}},
+		}
+		update := func(user string, groups ...string) admission.Request {
+			return admission.Request{AdmissionRequest: admissionv1.AdmissionRequest{
+				Operation: admissionv1.Update,
+				UserInfo:  authenticationv1.UserInfo{Username: user, Groups: groups},
+			}}
+		}
+
+		BeforeEach(func() {
+			oldObj.Spec = guardianv1alpha1.NamespaceRequestSpec{Tenant: "tenant-a", Env: "dev", OwnerGroup: "team-a"}
+			oldObj.Annotations = map[string]string{guardianv1alpha1.AnnRequestedBy: "alice"}
+		})
+
+		It("Should stamp the initiating owner and rewrite ownerGroup on accept", func() {
+			By("the current owner starting the transfer")
+			started := oldObj.DeepCopy()
+			started.Annotations[guardianv1alpha1.AnnTransferTo] = "team-b"
+			defaultTransferAnnotations(started, oldObj, "alice")
+			Expect(started.Annotations).To(HaveKeyWithValue(guardianv1alpha1.AnnTransferRequestedBy, "alice"))
+			_, denied := validateTransferRequest(update("alice", "team-a"), t, oldObj, started)
+			Expect(denied).To(BeFalse())
+
+			By("someone from the new group accepting it")
+			accepted := started.DeepCopy()
+			accepted.Annotations[guardianv1alpha1.AnnTransferAccept] = "true"
+			defaultTransferAnnotations(accepted, started, "bob")
+			Expect(accepted.Spec.OwnerGroup).To(Equal("team-b"))
+			Expect(accepted.Annotations).NotTo(HaveKey(guardianv1alpha1.AnnTransferTo))
+			Expect(accepted.Annotations).To(HaveKeyWithValue(guardianv1alpha1.AnnTransferAcceptedBy, "bob"))
+			Expect(isTransferAccept(started, accepted)).To(BeTrue())
+		})
+
+		It("Should deny a forged transfer-requested-by", func() {
+			forged := oldObj.DeepCopy()
+			forged.Annotations[guardianv1alpha1.AnnTransferTo] = "team-b"
+			forged.Annotations[guardianv1alpha1.AnnTransferRequestedBy] = "carol"
+			_, denied := validateTransferRequest(update("alice", "team-a"), t, oldObj, forged)
+			Expect(denied).To(BeTrue())
+		})
+
+		It("Should deny transfers to groups outside the tenant", func() {
+			for _, to := range []string{"system:authenticated", "tenant-b:dev", "team-c"} {
+				started := oldObj.DeepCopy()
+				started.Annotations[guardianv1alpha1.AnnTransferTo] = to
+				defaultTransferAnnotations(started, oldObj, "alice")
+				_, denied := validateTransferRequest(update("alice", "team-a"), t, oldObj, started)
+				Expect(denied).To(BeTrue(), to)
+			}
+
+			started := oldObj.DeepCopy()
+			started.Annotations[guardianv1alpha1.AnnTransferTo] = "tenant-a:qa"
+			defaultTransferAnnotations(started, oldObj, "alice")
+			_, denied := validateTransferRequest(update("alice", "team-a"), t, oldObj, started)
+			Expect(denied).To(BeFalse())
+		})
+
+		It("Should not treat a direct ownerGroup edit as an accepted transfer", func() {
+			edited := oldObj.DeepCopy()
+			edited.Spec.OwnerGroup = "team-b"
+			Expect(isTransferAccept(oldObj, edited)).To(BeFalse())
+		})
+	})
+
+	Context("When setting or extending a TTL", func() {
+		var t *guardianv1alpha1.Tenant
+
+		BeforeEach(func() {
+			t = &guardianv1alpha1.Tenant{
+				ObjectMeta: metav1.ObjectMeta{Name: "tenant-a"},
+				Spec: guardianv1alpha1.TenantSpec{
+					TTL: &guardianv1alpha1.TenantTTLSpec{
+						MaxByEnv: map[string]metav1.Duration{"dev": {Duration: 7 * 24 * time.Hour}},
+					},
+				},
+			}
+		})
+
+		It("Should require a ttl when the env is capped", func() {
+			Expect(validateRequestTTL(t, obj, "dev", time.Now())).NotTo(BeEmpty())
+			Expect(validateRequestTTL(t, obj, "test", time.Now())).To(BeEmpty())
+		})
+
+		It("Should cap the remaining lifetime after an extension", func() {
+			now := time.Now()
+			obj.CreationTimestamp = metav1.NewTime(now.Add(-6 * 24 * time.Hour))
+			obj.Spec.TTL = &metav1.Duration{Duration: 7 * 24 * time.Hour}
+
+			By("extending by 3 days from the current expiry")
+			obj.Annotations = map[string]string{guardianv1alpha1.AnnExtendTTL: "72h"}
+			applyTTLExtension(obj, now)
+			Expect(obj.Annotations).NotTo(HaveKey(guardianv1alpha1.AnnExtendTTL))
+			Expect(obj.Spec.TTL.Duration).To(Equal(10 * 24 * time.Hour))
+			Expect(validateRequestTTL(t, obj, "dev", now)).To(BeEmpty())
+
+			By("extending past the cap")
+			obj.Annotations[guardianv1alpha1.AnnExtendTTL] = "168h"
+			applyTTLExtension(obj, now)
+			Expect(validateRequestTTL(t, obj, "dev", now)).To(ContainSubstring("at most"))
+		})
 	})
 
 })
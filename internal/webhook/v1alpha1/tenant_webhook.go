@@ -0,0 +1,76 @@
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+
+	guardianv1alpha1 "github.com/CATDOGME/namespace-guardian/api/v1alpha1"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	admission "sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+var tenantlog = logf.Log.WithName("tenant-webhook")
+
+// SetupTenantWebhookWithManager registers the validating webhook for Tenant in the manager.
+func SetupTenantWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(&guardianv1alpha1.Tenant{}).
+		WithValidator(&TenantCustomValidator{}).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/validate-guardian-guardian-io-v1alpha1-tenant,mutating=false,failurePolicy=fail,sideEffects=None,groups=guardian.guardian.io,resources=tenants,verbs=create;update,versions=v1alpha1,name=vtenant-v1alpha1.kb.io,admissionReviewVersions=v1
+
+// TenantCustomValidator rejects Tenants whose baseline/regex/CIDR would only fail later at provisioning time.
+type TenantCustomValidator struct{}
+
+var _ webhook.CustomValidator = &TenantCustomValidator{}
+
+// ValidateCreate implements webhook.CustomValidator.
+func (v *TenantCustomValidator) ValidateCreate(_ context.Context, obj runtime.Object) (admission.Warnings, error) {
+	t, ok := obj.(*guardianv1alpha1.Tenant)
+	if !ok {
+		return nil, fmt.Errorf("expected Tenant but got %T", obj)
+	}
+	return nil, validateTenant(t)
+}
+
+// ValidateUpdate implements webhook.CustomValidator.
+func (v *TenantCustomValidator) ValidateUpdate(_ context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	t, ok := newObj.(*guardianv1alpha1.Tenant)
+	if !ok {
+		return nil, fmt.Errorf("expected Tenant but got %T", newObj)
+	}
+	old, ok := oldObj.(*guardianv1alpha1.Tenant)
+	if !ok {
+		return nil, fmt.Errorf("expected Tenant but got %T", oldObj)
+	}
+	// spec 没变（finalizer、标签、status 之外的元数据更新）或正在删除：不重新校验，
+	// 校验规则收紧后，旧 Tenant 仍能被 controller 摘 finalizer、被删除
+	if !t.DeletionTimestamp.IsZero() || equality.Semantic.DeepEqual(t.Spec, old.Spec) {
+		return nil, nil
+	}
+	return nil, validateTenant(t, guardianv1alpha1.ValidateTenantUpdate(&t.Spec, &old.Spec, field.NewPath("spec"))...)
+}
+
+// ValidateDelete implements webhook.CustomValidator.
+func (v *TenantCustomValidator) ValidateDelete(_ context.Context, _ runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+func validateTenant(t *guardianv1alpha1.Tenant, extra ...*field.Error) error {
+	errs := append(guardianv1alpha1.ValidateTenantSpec(&t.Spec, field.NewPath("spec")), extra...)
+	if len(errs) == 0 {
+		return nil
+	}
+	tenantlog.Info("tenant rejected", "tenant", t.Name, "errors", errs.ToAggregate().Error())
+	return apierrors.NewInvalid(guardianv1alpha1.GroupVersion.WithKind("Tenant").GroupKind(), t.Name, errs)
+}
@@ -0,0 +1,174 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	guardianv1alpha1 "github.com/CATDOGME/namespace-guardian/api/v1alpha1"
+)
+
+var _ = Describe("Tenant Webhook", func() {
+	var (
+		obj       *guardianv1alpha1.Tenant
+		validator TenantCustomValidator
+	)
+
+	BeforeEach(func() {
+		obj = &guardianv1alpha1.Tenant{
+			ObjectMeta: metav1.ObjectMeta{Name: "tenant-a"},
+			Spec: guardianv1alpha1.TenantSpec{
+				AllowedGroups: []string{"tenant-a:dev"},
+			},
+		}
+		validator = TenantCustomValidator{}
+	})
+
+	Context("When creating or updating Tenant under Validating Webhook", func() {
+		It("Should admit a minimal valid tenant", func() {
+			Expect(validator.ValidateCreate(ctx, obj)).Error().NotTo(HaveOccurred())
+		})
+
+		It("Should deny a bad quota quantity with its field path", func() {
+			obj.Spec.Baseline = &guardianv1alpha1.TenantBaselineSpec{
+				Quota: &guardianv1alpha1.TenantQuotaSpec{
+					ByEnv: map[string]guardianv1alpha1.QuotaHard{"prod": {RequestsCPU: "lots"}},
+				},
+			}
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(MatchError(ContainSubstring("spec.baseline.quota.byEnv[prod].requestsCPU")))
+		})
+
+		It("Should deny a namespaceNamePattern that does not compile", func() {
+			obj.Spec.NamespaceNamePattern = "^(tenant-a"
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(MatchError(ContainSubstring("spec.namespaceNamePattern")))
+		})
+
+		It("Should deny malformed CIDRs and unknown byEnv keys on update", func() {
+			oldObj := obj.DeepCopy()
+			obj.Spec.Baseline = &guardianv1alpha1.TenantBaselineSpec{
+				NetworkPolicy: &guardianv1alpha1.TenantNetworkPolicySpec{
+					AllowEgressCIDRs: []string{"10.0.0.0/33"},
+					ByEnv: map[string]guardianv1alpha1.TenantNetworkPolicyEnvOverride{
+						"staging": {Profile: guardianv1alpha1.NPProfileStrict},
+					},
+				},
+			}
+			_, err := validator.ValidateUpdate(ctx, oldObj, obj)
+			Expect(err).To(MatchError(ContainSubstring("spec.baseline.networkPolicy.allowEgressCIDRs[0]")))
+			Expect(err).To(MatchError(ContainSubstring("spec.baseline.networkPolicy.byEnv[staging]")))
+		})
+
+		It("Should not re-validate an unchanged or deleting spec on update", func() {
+			obj.Spec.NamespaceNamePattern = "^(tenant-a"
+			oldObj := obj.DeepCopy()
+			obj.Labels = map[string]string{"team": "a"}
+			Expect(validator.ValidateUpdate(ctx, oldObj, obj)).Error().NotTo(HaveOccurred())
+
+			obj.Spec.AllowedGroups = append(obj.Spec.AllowedGroups, "tenant-a:test")
+			_, err := validator.ValidateUpdate(ctx, oldObj, obj)
+			Expect(err).To(MatchError(ContainSubstring("spec.namespaceNamePattern")))
+
+			now := metav1.Now()
+			obj.DeletionTimestamp = &now
+			Expect(validator.ValidateUpdate(ctx, oldObj, obj)).Error().NotTo(HaveOccurred())
+		})
+
+		It("Should deny unknown and duplicate rollout stages", func() {
+			obj.Spec.Rollout = &guardianv1alpha1.TenantRolloutSpec{Stages: []string{"dev", "staging", "dev"}}
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(MatchError(ContainSubstring("spec.rollout.stages[1]")))
+			Expect(err).To(MatchError(ContainSubstring("spec.rollout.stages[2]")))
+		})
+
+		It("Should deny lowering the baseline version", func() {
+			obj.Spec.Baseline = &guardianv1alpha1.TenantBaselineSpec{Version: guardianv1alpha1.BaselineVersionV2}
+			oldObj := obj.DeepCopy()
+			Expect(validator.ValidateUpdate(ctx, &guardianv1alpha1.Tenant{Spec: obj.Spec}, obj)).Error().NotTo(HaveOccurred())
+
+			obj.Spec.Baseline.Version = guardianv1alpha1.BaselineVersionV1
+			_, err := validator.ValidateUpdate(ctx, oldObj, obj)
+			Expect(err).To(MatchError(ContainSubstring("spec.baseline.version")))
+
+			obj.Spec.Baseline = nil
+			_, err = validator.ValidateUpdate(ctx, oldObj, obj)
+			Expect(err).To(MatchError(ContainSubstring("cannot downgrade baseline from v2 to v1")))
+		})
+
+		It("Should deny invalid and duplicate baseline ServiceAccount names", func() {
+			obj.Spec.Baseline = &guardianv1alpha1.TenantBaselineSpec{
+				ServiceAccounts: &guardianv1alpha1.TenantServiceAccountSpec{
+					ByEnv: map[string][]guardianv1alpha1.BaselineServiceAccount{
+						"dev": {{Name: "deployer"}, {Name: "deployer"}, {Name: "CI_Bot"}},
+					},
+				},
+			}
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(MatchError(ContainSubstring("spec.baseline.serviceAccounts.byEnv[dev][1].name")))
+			Expect(err).To(MatchError(ContainSubstring("spec.baseline.serviceAccounts.byEnv[dev][2].name")))
+		})
+
+		It("Should deny the default ServiceAccount and ClusterRoles outside the role catalog", func() {
+			obj.Spec.Baseline = &guardianv1alpha1.TenantBaselineSpec{
+				RBAC: &guardianv1alpha1.TenantRBACSpec{RoleCatalog: []string{"view"}},
+				ServiceAccounts: &guardianv1alpha1.TenantServiceAccountSpec{
+					Default: []guardianv1alpha1.BaselineServiceAccount{
+						{Name: "default"},
+						{Name: "deployer", ClusterRole: guardianv1alpha1.DefaultOwnerClusterRole},
+						{Name: "reader", ClusterRole: "view"},
+						{Name: "root", ClusterRole: "cluster-admin"},
+					},
+				},
+			}
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(MatchError(ContainSubstring("spec.baseline.serviceAccounts.default[0].name")))
+			Expect(err).To(MatchError(ContainSubstring("spec.baseline.serviceAccounts.default[3].clusterRole")))
+			Expect(err).NotTo(MatchError(ContainSubstring("default[1]")))
+			Expect(err).NotTo(MatchError(ContainSubstring("default[2]")))
+		})
+
+		It("Should deny unknown components and policies in baseline conflicts", func() {
+			obj.Spec.Baseline = &guardianv1alpha1.TenantBaselineSpec{
+				Conflicts: &guardianv1alpha1.TenantConflictSpec{
+					ByComponent: map[string]string{"Quota": "Report", "Secrets": "Force", "RBAC": "Ignore"},
+				},
+			}
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(MatchError(ContainSubstring(`Unsupported value: "Secrets"`)))
+			Expect(err).To(MatchError(ContainSubstring("spec.baseline.conflicts.byComponent[RBAC]")))
+			Expect(err).NotTo(MatchError(ContainSubstring("byComponent[Quota]")))
+		})
+
+		It("Should deny baseline manifests whose template does not render to an object", func() {
+			obj.Spec.Baseline = &guardianv1alpha1.TenantBaselineSpec{
+				Manifests: []guardianv1alpha1.BaselineManifest{
+					{Name: "pdb", Template: "apiVersion: policy/v1\nkind: PodDisruptionBudget\nmetadata:\n  name: \"{tenant}-pdb\"\n"},
+					{Name: "broken", Template: "kind: ConfigMap\nmetadata: {}\n"},
+					{Name: "pdb", Envs: []string{"staging"}, Template: "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: x\n"},
+				},
+			}
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(MatchError(ContainSubstring("spec.baseline.manifests[1].template")))
+			Expect(err).To(MatchError(ContainSubstring("spec.baseline.manifests[2].name")))
+			Expect(err).NotTo(MatchError(ContainSubstring("spec.baseline.manifests[0]")))
+		})
+	})
+})
@@ -109,9 +109,15 @@ var _ = BeforeSuite(func() {
 	})
 	Expect(err).NotTo(HaveOccurred())
 
+	// controller 身份由 Deployment 注入，这里按 config/manager/manager.yaml 的默认值设置
+	GinkgoT().Setenv(EnvPodNamespace, "namespace-guardian-system")
+	GinkgoT().Setenv(EnvServiceAccountName, "namespace-guardian-controller-manager")
 	err = SetupNamespaceRequestWebhookWithManager(mgr)
 	Expect(err).NotTo(HaveOccurred())
 
+	err = SetupTenantWebhookWithManager(mgr)
+	Expect(err).NotTo(HaveOccurred())
+
 	// +kubebuilder:scaffold:webhook
 
 	go func() {